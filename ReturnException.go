@@ -1,5 +0,0 @@
-package main
-
-type RuntimeReturn struct {
-	value LoxValue
-}
@@ -12,25 +12,41 @@ var stmtTypes = [][]string{
 	{"Expression", "expression Expr"},
 	{"If", "condition Expr", "thenBranch Stmt", "elseBranch Stmt"},
 	{"Print", "expression Expr"},
-	{"While", "condition Expr", "body Stmt"},
+	{"While", "keyword Token", "condition Expr", "body Stmt", "increment Expr"},
 	{"Var", "name Token", "initializer Expr", "isConst bool"},
 	{"Function", "name Token", "params []Token", "body []Stmt"},
 	{"Return", "keyword Token", "value Expr"},
+	{"Class", "name Token", "superclass Expr", "methods []Function"},
+	{"Break", "keyword Token"},
+	{"Continue", "keyword Token"},
+	{"Throw", "keyword Token", "value Expr"},
+	{"Import", "keyword Token", "path Token", "hasName bool", "name Token"},
+	{"Try", "keyword Token", "body []Stmt", "hasCatch bool", "catchName Token", "catchBody []Stmt", "finallyBody []Stmt"},
 }
 
 var exprTypes = [][]string{
-	{"Assign", "name Token", "value Expr"},
+	{"Assign", "name Token", "value Expr", "depth *int"},
 	{"Binary", "left Expr", "operator Token", "right Expr"},
 	{"Call", "callee Expr", "paren Token", "arguments []Expr"},
 	{"Grouping", "expression Expr"},
 	{"Literal", "value LoxValue"},
 	{"Logical", "left Expr", "operator Token", "right Expr"},
 	{"Unary", "operator Token", "right Expr"},
-	{"Variable", "name Token"},
+	{"Variable", "name Token", "depth *int"},
+	{"Get", "object Expr", "name Token"},
+	{"Set", "object Expr", "name Token", "value Expr"},
+	{"This", "keyword Token", "depth *int"},
+	{"Super", "keyword Token", "method Token", "depth *int"},
+	{"List", "bracket Token", "elements []Expr"},
+	{"Dict", "brace Token", "keys []Expr", "values []Expr"},
+	{"Lambda", "declaration Function"},
+	{"Interpolation", "quote Token", "parts []Expr"},
+	{"Index", "object Expr", "bracket Token", "index Expr"},
+	{"IndexSet", "object Expr", "bracket Token", "index Expr", "value Expr"},
 }
 
 func createAstTypes(varType string) {
-	filename := fmt.Sprintf("../%sTypes.go", varType)
+	filename := fmt.Sprintf("../internal/engine/%sTypes.go", varType)
 	file, err := os.Create(filename)
 
 	if err != nil {
@@ -39,7 +55,7 @@ func createAstTypes(varType string) {
 
 	defer file.Close()
 
-	_, writeErr := file.WriteString("package main\n\n")
+	_, writeErr := file.WriteString("package engine\n\n")
 
 	if writeErr != nil {
 		log.Fatal(writeErr)
@@ -60,6 +76,9 @@ func createAstTypes(varType string) {
 		typeStr += fmt.Sprintf("func (%sObj %s) accept(visitor Interpreter) LoxValue {\n", strings.ToLower(varTypeToWrite[i][0]), varTypeToWrite[i][0])
 		typeStr += fmt.Sprintf("	return visitor.visit%s%s(%sObj)\n", varTypeToWrite[i][0], varType, strings.ToLower(varTypeToWrite[i][0]))
 		typeStr += fmt.Sprintf("}\n\n")
+		typeStr += fmt.Sprintf("func (%sObj %s) resolve(resolver *Resolver) {\n", strings.ToLower(varTypeToWrite[i][0]), varTypeToWrite[i][0])
+		typeStr += fmt.Sprintf("	resolver.visit%s%s(%sObj)\n", varTypeToWrite[i][0], varType, strings.ToLower(varTypeToWrite[i][0]))
+		typeStr += fmt.Sprintf("}\n\n")
 		file.WriteString(typeStr)
 	}
 }
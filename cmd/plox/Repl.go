@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/peterh/liner"
+
+	"workspace/lox"
+)
+
+const HISTORY_FILE = ".plox_history"
+
+const REPL_HELP = `:env            list the global bindings
+:load <file>    run a file in the current session
+:reset          discard every binding
+:ast <code>     show the syntax tree of the code
+:tokens <code>  show the tokens of the code
+:quit           leave the REPL`
+
+type Repl struct {
+	vm     *lox.VM
+	line   *liner.State
+	buffer string
+}
+
+func isTerminal(file *os.File) bool {
+	/*Determines if the given file is
+	an interactive terminal.
+	*/
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func runRepl() {
+	/*Prompts the user to enter
+	code. Lines are collected with a
+	continuation prompt until the input
+	is a complete statement, which then
+	gets evaluated by the virtual machine
+	and the values of expressions are
+	printed. An empty line evaluates the
+	input as it is. Errors are reported
+	and the session continues with its
+	globals intact. Pressing Ctrl-C while
+	the code runs stops it.
+	*/
+	var repl Repl
+	repl.init()
+	defer repl.close()
+
+	for {
+		prompt := "> "
+		if repl.buffer != "" {
+			prompt = "... "
+		}
+		userInput, err := repl.readLine(prompt)
+
+		if err == liner.ErrPromptAborted {
+			repl.buffer = ""
+			continue
+		} else if err != nil {
+			if err != io.EOF {
+				fmt.Println(err)
+			}
+			fmt.Println()
+			break
+		}
+		if strings.TrimSpace(userInput) != "" && repl.line != nil {
+			repl.line.AppendHistory(userInput)
+		}
+
+		if repl.buffer == "" && strings.HasPrefix(strings.TrimSpace(userInput), ":") {
+			if !repl.runCommand(strings.TrimSpace(userInput)) {
+				break
+			}
+			continue
+		}
+
+		forceEval := repl.buffer != "" && strings.TrimSpace(userInput) == ""
+		repl.buffer += userInput + "\n"
+		if !forceEval && lox.IsIncomplete(repl.buffer) {
+			continue
+		}
+
+		srcCode := repl.buffer
+		err = repl.evaluate(func(ctx context.Context) error {
+			return repl.vm.EvalReplContext(ctx, srcCode)
+		})
+		repl.buffer = ""
+		reportError(repl.vm, err)
+	}
+}
+
+func (repl *Repl) init() {
+	/*Initializes the REPL with a new
+	virtual machine and a line editor
+	that remembers the history of
+	previous sessions. When the input is
+	not a terminal, lines are read from a
+	reader shared with the input function
+	instead, so piped input is not lost.
+	*/
+	repl.buffer = ""
+	if !isTerminal(os.Stdin) {
+		stdinReader = bufio.NewReader(os.Stdin)
+		repl.vm = newVM()
+		return
+	}
+	repl.vm = newVM()
+	repl.line = liner.NewLiner()
+	repl.line.SetCtrlCAborts(true)
+	repl.line.SetCompleter(repl.complete)
+
+	if historyFile, err := os.Open(repl.historyPath()); err == nil {
+		repl.line.ReadHistory(historyFile)
+		historyFile.Close()
+	}
+}
+
+func (repl *Repl) evaluate(run func(ctx context.Context) error) error {
+	/*Runs code of the user with the given
+	function. An interrupt signal cancels the
+	code instead of ending the REPL, and the
+	-timeout flag applies to every evaluation.
+	*/
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		defer cancelTimeout()
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-interrupts:
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return run(ctx)
+}
+
+func (repl *Repl) readLine(prompt string) (string, error) {
+	/*Prompts the user for a line of input.
+	Without a terminal the line is read from
+	the shared reader, and a last line without
+	a new line character is still returned.
+	*/
+	if repl.line != nil {
+		return repl.line.Prompt(prompt)
+	}
+	fmt.Print(prompt)
+	userInput, err := stdinReader.ReadString('\n')
+	if err != nil && userInput == "" {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(userInput, "\n"), "\r"), nil
+}
+
+func (repl *Repl) close() {
+	/*Saves the history and gives the
+	terminal back to the user.
+	*/
+	if repl.line == nil {
+		return
+	}
+	if historyFile, err := os.Create(repl.historyPath()); err == nil {
+		repl.line.WriteHistory(historyFile)
+		historyFile.Close()
+	}
+	repl.line.Close()
+}
+
+func (repl *Repl) historyPath() string {
+	/*Returns the path of the history
+	file in the home directory of the
+	user.
+	*/
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return HISTORY_FILE
+	}
+	return filepath.Join(homeDir, HISTORY_FILE)
+}
+
+func (repl *Repl) complete(userInput string) []string {
+	/*Returns the completions of the word
+	being typed using the keywords of the
+	language and the global bindings.
+	*/
+	start := len(userInput)
+	for start > 0 && isWordCharacter(userInput[start-1]) {
+		start--
+	}
+	word := userInput[start:]
+	if word == "" {
+		return nil
+	}
+
+	var completions []string
+	candidates := append(lox.Keywords(), repl.vm.Globals()...)
+	for i := 0; i < len(candidates); i++ {
+		if strings.HasPrefix(candidates[i], word) {
+			completions = append(completions, userInput[:start]+candidates[i])
+		}
+	}
+	return completions
+}
+
+func (repl *Repl) runCommand(command string) bool {
+	/*Runs a REPL command starting with
+	':'. Returns false if the REPL
+	should stop.
+	*/
+	name, argument := command, ""
+	if index := strings.IndexAny(command, " \t"); index != -1 {
+		name, argument = command[:index], strings.TrimSpace(command[index+1:])
+	}
+
+	switch name {
+	case ":quit", ":q":
+		return false
+	case ":help":
+		fmt.Println(REPL_HELP)
+	case ":env":
+		globals := repl.vm.Globals()
+		for i := 0; i < len(globals); i++ {
+			value, _ := repl.vm.Inspect(globals[i])
+			fmt.Printf("%s = %s\n", globals[i], value)
+		}
+	case ":load":
+		if argument == "" {
+			fmt.Println("Usage: :load <file>")
+		} else {
+			reportError(repl.vm, repl.evaluate(func(ctx context.Context) error {
+				return repl.vm.LoadFileContext(ctx, argument)
+			}))
+		}
+	case ":reset":
+		repl.vm = newVM()
+	case ":ast":
+		ast, err := lox.FormatAST(argument)
+		repl.printResult(ast, err)
+	case ":tokens":
+		tokens, err := lox.FormatTokens(argument)
+		repl.printResult(tokens, err)
+	default:
+		fmt.Printf("Unknown command '%s'. Type :help for a list of commands.\n", name)
+	}
+	return true
+}
+
+func (repl *Repl) printResult(result string, err error) {
+	/*Prints the result of a command
+	or the error it returned.
+	*/
+	if err != nil {
+		reportError(repl.vm, err)
+	} else {
+		fmt.Println(result)
+	}
+}
+
+func isWordCharacter(char byte) bool {
+	/*Determines if the character can
+	be part of an identifier.
+	*/
+	return char == '_' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"workspace/lox"
+)
+
+var jsonDiagnostics bool
+var maxCallDepth int
+var maxSteps int64
+var timeout time.Duration
+var profile string
+var denyNames string
+var denied []lox.Capability
+var stdinReader *bufio.Reader
+
+func newVM() *lox.VM {
+	/*Returns a virtual machine configured
+	with the options given on the command
+	line. The input function of every virtual
+	machine reads from stdinReader when the
+	REPL shares it.
+	*/
+	vm := lox.NewVM()
+	if stdinReader != nil {
+		vm.SetStdin(stdinReader)
+	}
+	vm.SetMaxCallDepth(maxCallDepth)
+	vm.SetMaxSteps(maxSteps)
+	if err := vm.UseProfile(profile); err != nil {
+		log.Fatal(err)
+	}
+	vm.Deny(denied...)
+	return vm
+}
+
+func reportError(vm *lox.VM, err error) {
+	/*Displays the error returned by the
+	virtual machine on its stderr, as lines
+	of JSON when the -json flag is given.
+	*/
+	if err == nil {
+		return
+	} else if !isCompileError(err) && !isRuntimeError(err) {
+		fmt.Fprintf(vm.Stderr(), "Failed opening file: %s\n", err)
+	} else if !jsonDiagnostics {
+		vm.ReportError(err)
+	} else if compileErr, isCompileError := err.(*lox.CompileError); isCompileError {
+		for i := 0; i < len(compileErr.Diagnostics); i++ {
+			encoded, _ := json.Marshal(compileErr.Diagnostics[i])
+			fmt.Fprintln(vm.Stderr(), string(encoded))
+		}
+	} else {
+		encoded, _ := json.Marshal(err)
+		fmt.Fprintln(vm.Stderr(), string(encoded))
+	}
+}
+
+func parseCapabilities(names string) ([]lox.Capability, error) {
+	/*Returns the capabilities listed in
+	the given comma separated names.
+	*/
+	var capabilities []lox.Capability
+	for _, name := range strings.Split(names, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		capability, err := lox.ParseCapability(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities, nil
+}
+
+func isRuntimeError(err error) bool {
+	/*Determines if the error was raised
+	while running the program.
+	*/
+	_, isRuntimeErr := err.(*lox.RuntimeError)
+	return isRuntimeErr
+}
+
+func isCompileError(err error) bool {
+	/*Determines if the error was raised
+	while compiling the program.
+	*/
+	_, isCompileErr := err.(*lox.CompileError)
+	return isCompileErr
+}
+
+func runFile(filePath string) {
+	/*Reads the whole file of given
+	path and runs it. Runtime errors
+	end the program with exit status 70,
+	as does running past the timeout.
+	*/
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	vm := newVM()
+	err := vm.RunFileContext(ctx, filePath)
+	if err != nil && !isRuntimeError(err) && !isCompileError(err) {
+		log.Fatalf("Failed opening file: %s", err)
+	}
+	reportError(vm, err)
+	if isRuntimeError(err) {
+		os.Exit(70)
+	}
+}
+
+func main() {
+	flag.BoolVar(&jsonDiagnostics, "json", false, "report errors as JSON lines on stderr")
+	flag.IntVar(&maxCallDepth, "max-depth", lox.DEFAULT_MAX_CALL_DEPTH, "maximum depth of nested calls, 0 for no limit")
+	flag.Int64Var(&maxSteps, "max-steps", 0, "maximum number of steps a program can take, 0 for no limit")
+	flag.DurationVar(&timeout, "timeout", 0, "stop the program after the given duration, 0 for no limit")
+	flag.StringVar(&profile, "profile", lox.FULL_PROFILE, "capabilities granted to the program: "+strings.Join(lox.Profiles(), " or "))
+	flag.StringVar(&denyNames, "deny", "", "comma separated capabilities to take away: time, stdin, filesystem")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: plox [options] [script]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	var err error
+	if denied, err = parseCapabilities(denyNames); err != nil {
+		log.Fatal(err)
+	}
+	args := flag.Args()
+
+	if len(args) > 1 {
+		flag.Usage()
+	} else if len(args) == 1 {
+		runFile(args[0])
+	} else {
+		runRepl()
+	}
+}
@@ -3,6 +3,7 @@ module workspace
 go 1.13
 
 require (
+	github.com/peterh/liner v1.2.2
 	github.com/stamblerre/gocode v1.0.0 // indirect
 	golang.org/x/tools/gopls v0.8.3 // indirect
 )
@@ -1,14 +1,17 @@
-package main
+package engine
 
 type Expr interface {
 	accept(visitor Interpreter) LoxValue
+	resolve(resolver *Resolver)
 }
 
 type Stmt interface {
 	accept(visitor Interpreter) LoxValue
+	resolve(resolver *Resolver)
 }
 
 type LoxCallable interface {
+	arity() int
 	call(interpreter Interpreter, arguments []LoxValue) LoxValue
 }
 
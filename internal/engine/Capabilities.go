@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Capability string
+
+const (
+	TIME_CAPABILITY       Capability = "time"
+	STDIN_CAPABILITY      Capability = "stdin"
+	FILESYSTEM_CAPABILITY Capability = "filesystem"
+)
+
+const (
+	FULL_PROFILE = "full"
+	PURE_PROFILE = "pure"
+)
+
+var profiles = map[string][]Capability{
+	FULL_PROFILE: {TIME_CAPABILITY, STDIN_CAPABILITY, FILESYSTEM_CAPABILITY},
+	PURE_PROFILE: {},
+}
+
+func ParseCapability(name string) (Capability, error) {
+	/*Returns the capability with the given
+	name. Fails if there is no such capability.
+	*/
+	capabilities := profiles[FULL_PROFILE]
+	for i := 0; i < len(capabilities); i++ {
+		if string(capabilities[i]) == name {
+			return capabilities[i], nil
+		}
+	}
+	return "", fmt.Errorf("unknown capability '%s'", name)
+}
+
+func Profiles() []string {
+	/*Returns the names of the capability
+	profiles in alphabetical order.
+	*/
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func capabilitySet(capabilities []Capability) map[Capability]bool {
+	/*Returns the given capabilities as a
+	set of granted capabilities.
+	*/
+	granted := map[Capability]bool{}
+	for i := 0; i < len(capabilities); i++ {
+		granted[capabilities[i]] = true
+	}
+	return granted
+}
+
+func profileCapabilities(name string) ([]Capability, error) {
+	/*Returns the capabilities granted by
+	the profile with the given name.
+	*/
+	capabilities, isProfile := profiles[name]
+	if !isProfile {
+		return nil, fmt.Errorf("unknown profile '%s', expected one of: %s", name, strings.Join(Profiles(), ", "))
+	}
+	return capabilities, nil
+}
+
+func deniedMessage(capability Capability, action string) string {
+	/*Returns the message of the runtime
+	error raised when a script does something
+	that needs a capability it was not granted.
+	*/
+	return fmt.Sprintf("Cannot %s: capability '%s' is not granted", action, capability)
+}
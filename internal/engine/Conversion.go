@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+func toLoxValue(value interface{}) (LoxValue, error) {
+	/*Converts a Go value into a lox value.
+	Integers become int64, floats become
+	float64, slices become lists and maps
+	become dictionaries.
+	*/
+	if value == nil {
+		return nil, nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Bool:
+		return reflected.Bool(), nil
+	case reflect.String:
+		return reflected.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflected.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if reflected.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("cannot convert %d to a lox int", reflected.Uint())
+		}
+		return int64(reflected.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float(), nil
+	case reflect.Slice, reflect.Array:
+		list := &LoxList{elements: []LoxValue{}}
+		for i := 0; i < reflected.Len(); i++ {
+			element, err := toLoxValue(reflected.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			list.elements = append(list.elements, element)
+		}
+		return list, nil
+	case reflect.Map:
+		dict := &LoxDict{}
+		dict.init()
+		mapKeys := reflected.MapKeys()
+		sort.Slice(mapKeys, func(i, j int) bool {
+			return isMapKeyLess(mapKeys[i], mapKeys[j])
+		})
+		for _, mapKey := range mapKeys {
+			key, err := toLoxValue(mapKey.Interface())
+			if err != nil {
+				return nil, err
+			}
+			dictKey, isValid := dict.normalizeKey(key)
+			if !isValid {
+				return nil, fmt.Errorf("cannot use %v as a dictionary key", mapKey.Interface())
+			}
+			entry, err := toLoxValue(reflected.MapIndex(mapKey).Interface())
+			if err != nil {
+				return nil, err
+			}
+			if _, inDict := dict.entries[dictKey]; !inDict {
+				dict.keys = append(dict.keys, dictKey)
+			}
+			dict.entries[dictKey] = entry
+		}
+		return dict, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert value of type %T to a lox value", value)
+}
+
+func isMapKeyLess(left, right reflect.Value) bool {
+	/*Orders the keys of a Go map so the
+	dictionary built from it always has the
+	same order. Keys inside interfaces are
+	unwrapped first. Numbers come before
+	strings, and keys of other kinds are
+	ordered by their printed form.
+	*/
+	for left.Kind() == reflect.Interface && !left.IsNil() {
+		left = left.Elem()
+	}
+	for right.Kind() == reflect.Interface && !right.IsNil() {
+		right = right.Elem()
+	}
+
+	leftNumber, isLeftNumber := mapKeyNumber(left)
+	rightNumber, isRightNumber := mapKeyNumber(right)
+	if isLeftNumber && isRightNumber {
+		return leftNumber < rightNumber
+	} else if isLeftNumber != isRightNumber {
+		return isLeftNumber
+	}
+	return fmt.Sprint(left.Interface()) < fmt.Sprint(right.Interface())
+}
+
+func mapKeyNumber(key reflect.Value) (float64, bool) {
+	/*Returns the numeric value of a map
+	key, if the key is a number.
+	*/
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(key.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(key.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return key.Float(), true
+	}
+	return 0, false
+}
+
+func toGoValue(value LoxValue) (interface{}, error) {
+	/*Converts a lox value into a Go value.
+	Lists become []interface{} and dictionaries
+	become map[interface{}]interface{}. Functions,
+	classes, instances and containers that
+	contain themselves can't be converted.
+	*/
+	return toGoValueNested(value, map[LoxValue]bool{})
+}
+
+func toGoValueNested(value LoxValue, converting map[LoxValue]bool) (interface{}, error) {
+	/*Converts a lox value into a Go value.
+	converting holds the lists and dictionaries
+	that are being converted, so a container
+	that contains itself is reported instead
+	of recursing forever.
+	*/
+	if value == nil {
+		return nil, nil
+	} else if boolValue, isBool := value.(bool); isBool {
+		return boolValue, nil
+	} else if intValue, isInt := value.(int64); isInt {
+		return intValue, nil
+	} else if floatValue, isFloat := value.(float64); isFloat {
+		return floatValue, nil
+	} else if strValue, isString := value.(string); isString {
+		return strValue, nil
+	} else if list, isList := value.(*LoxList); isList {
+		if converting[list] {
+			return nil, fmt.Errorf("cannot convert a list that contains itself to a Go value")
+		}
+		converting[list] = true
+		defer delete(converting, list)
+
+		elements := make([]interface{}, len(list.elements))
+		for i := 0; i < len(list.elements); i++ {
+			element, err := toGoValueNested(list.elements[i], converting)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+		return elements, nil
+	} else if dict, isDict := value.(*LoxDict); isDict {
+		if converting[dict] {
+			return nil, fmt.Errorf("cannot convert a dictionary that contains itself to a Go value")
+		}
+		converting[dict] = true
+		defer delete(converting, dict)
+
+		entries := make(map[interface{}]interface{}, len(dict.keys))
+		for i := 0; i < len(dict.keys); i++ {
+			entry, err := toGoValueNested(dict.entries[dict.keys[i]], converting)
+			if err != nil {
+				return nil, err
+			}
+			entries[dict.keys[i]] = entry
+		}
+		return entries, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert '%v' to a Go value", value)
+}
@@ -0,0 +1,99 @@
+package engine
+
+const (
+	EVAL_SOURCE = "<eval>"
+	REPL_SOURCE = "<repl>"
+)
+
+const DEFAULT_MAX_CALL_DEPTH = 10000
+
+func runLexer(source *Source) ([]Token, []Diagnostic) {
+	/*Runs the scanner using
+	the given source code
+	and returns the resulting
+	array of tokens along with
+	the errors found.
+	*/
+	var scnr Scanner
+	scnr.init(source)
+	return scnr.runScanner(), scnr.errors
+}
+
+func runParser(tokenArr []Token, replMode bool) ([]Stmt, []Diagnostic) {
+	/*Runs the parser using
+	the given token array
+	and returns the resulting
+	AST along with the errors
+	found.
+	*/
+	var parser Parser
+	parser.init(tokenArr)
+	parser.replMode = replMode
+	return parser.parseTokens(), parser.errors
+}
+
+func runResolver(resolver Resolver, stmtArr []Stmt) (Resolver, []Diagnostic) {
+	/*Runs the given resolver over the
+	given AST and returns the modified
+	resolver along with the errors found.
+	*/
+	resolver.resolveProgram(stmtArr)
+	return resolver, resolver.errors
+}
+
+func compile(resolver Resolver, source *Source, replMode bool) ([]Stmt, Resolver, error) {
+	/*Scans, parses and resolves the given
+	source code. Returns the modified resolver
+	and a CompileError if any of the stages
+	found errors.
+	*/
+	tokenArr, scnrErrors := runLexer(source)
+	if len(scnrErrors) > 0 {
+		return nil, resolver, &CompileError{Diagnostics: scnrErrors}
+	}
+	stmtArr, parserErrors := runParser(tokenArr, replMode)
+	if len(parserErrors) > 0 {
+		return nil, resolver, &CompileError{Diagnostics: parserErrors}
+	}
+	resolver, resolverErrors := runResolver(resolver, stmtArr)
+	if len(resolverErrors) > 0 {
+		return nil, resolver, &CompileError{Diagnostics: resolverErrors}
+	}
+	return stmtArr, resolver, nil
+}
+
+func IsIncomplete(srcCode string) bool {
+	/*Determines if the given source code
+	ends in the middle of a statement. This
+	happens when a string is not terminated,
+	brackets are not balanced or the parser
+	reaches the end while expecting more.
+	Uses the rules of the REPL, where the
+	last expression needs no semicolon.
+	*/
+	var scnr Scanner
+	scnr.init(&Source{name: REPL_SOURCE, text: srcCode})
+	tokenArr := scnr.runScanner()
+	if len(scnr.errors) > 0 {
+		return scnr.unterminated
+	}
+
+	depth := 0
+	for i := 0; i < len(tokenArr); i++ {
+		tokenType := tokenArr[i].tokenType
+		if tokenType == LEFT_PAREN || tokenType == LEFT_BRACE || tokenType == LEFT_BRACKET {
+			depth++
+		} else if tokenType == RIGHT_PAREN || tokenType == RIGHT_BRACE || tokenType == RIGHT_BRACKET {
+			depth--
+		}
+	}
+	if depth > 0 {
+		return true
+	}
+
+	var parser Parser
+	parser.init(tokenArr)
+	parser.replMode = true
+	parser.parseTokens()
+	return parser.incomplete
+}
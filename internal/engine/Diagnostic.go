@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	COMPILE_ERROR = "error"
+	RUNTIME_ERROR = "runtime error"
+)
+
+type Source struct {
+	name string
+	text string
+}
+
+type Diagnostic struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Offset  int    `json:"offset"`
+	Length  int    `json:"length"`
+	Snippet string `json:"snippet"`
+}
+
+func (source *Source) position(offset int) (int, int) {
+	/*Returns the line and column of the
+	character at the given byte offset.
+	*/
+	if offset > len(source.text) {
+		offset = len(source.text)
+	}
+	lineStart := strings.LastIndex(source.text[:offset], "\n") + 1
+	line := strings.Count(source.text[:offset], "\n") + 1
+	column := utf8.RuneCountInString(source.text[lineStart:offset]) + 1
+	return line, column
+}
+
+func (source *Source) lineAt(offset int) string {
+	/*Returns the text of the line containing
+	the given byte offset without its new
+	line character.
+	*/
+	if offset > len(source.text) {
+		offset = len(source.text)
+	}
+	lineStart := strings.LastIndex(source.text[:offset], "\n") + 1
+	lineEnd := strings.Index(source.text[offset:], "\n")
+	if lineEnd == -1 {
+		return strings.TrimRight(source.text[lineStart:], "\r")
+	}
+	return strings.TrimRight(source.text[lineStart:offset+lineEnd], "\r")
+}
+
+func newDiagnostic(kind string, source *Source, offset int, length int, message string) Diagnostic {
+	/*Creates a diagnostic pointing at the
+	given span of the source code. The span
+	is cut at the end of its first line.
+	*/
+	line, column := source.position(offset)
+	snippet := source.lineAt(offset)
+	if remaining := utf8.RuneCountInString(snippet) - column + 1; length > remaining {
+		length = remaining
+	}
+	if length < 1 {
+		length = 1
+	}
+
+	return Diagnostic{
+		Kind:    kind,
+		Message: strings.TrimSuffix(message, "."),
+		File:    source.name,
+		Line:    line,
+		Column:  column,
+		Offset:  offset,
+		Length:  length,
+		Snippet: snippet,
+	}
+}
+
+func tokenDiagnostic(kind string, token Token, message string) Diagnostic {
+	/*Creates a diagnostic pointing at the
+	given token. Tokens made up by the
+	interpreter have no source and only
+	keep their line.
+	*/
+	if token.source == nil {
+		return Diagnostic{Kind: kind, Message: strings.TrimSuffix(message, "."), Line: token.line, Column: token.column, Length: 1}
+	}
+	return newDiagnostic(kind, token.source, token.offset, utf8.RuneCountInString(token.lexeme), message)
+}
+
+func (diagnostic Diagnostic) String() string {
+	/*Renders the diagnostic with its position,
+	the offending line of source code and a
+	caret under the code it refers to.
+	*/
+	location := diagnostic.File
+	if location == "" && diagnostic.Line == 0 {
+		location = diagnostic.Kind
+	} else if location == "" {
+		location = fmt.Sprintf("line %d: %s", diagnostic.Line, diagnostic.Kind)
+	} else {
+		location = fmt.Sprintf("%s:%d:%d: %s", diagnostic.File, diagnostic.Line, diagnostic.Column, diagnostic.Kind)
+	}
+	header := fmt.Sprintf("%s: %s.", location, diagnostic.Message)
+	if diagnostic.Snippet == "" {
+		return header
+	}
+
+	var padding strings.Builder
+	snippetRunes := []rune(diagnostic.Snippet)
+	for i := 0; i < diagnostic.Column-1 && i < len(snippetRunes); i++ {
+		if snippetRunes[i] == '\t' {
+			padding.WriteRune('\t')
+		} else {
+			padding.WriteRune(' ')
+		}
+	}
+	lineNumber := fmt.Sprintf("%d", diagnostic.Line)
+	gutter := strings.Repeat(" ", len(lineNumber))
+
+	return fmt.Sprintf("%s\n %s | %s\n %s | %s%s", header, lineNumber, diagnostic.Snippet, gutter, padding.String(), strings.Repeat("^", diagnostic.Length))
+}
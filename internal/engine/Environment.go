@@ -1,13 +1,13 @@
-package main
+package engine
 
 import (
 	"fmt"
 )
 
 type Environment struct {
-	values      map[string]LoxValue
-	constValues map[string]bool
-	enclosing   *Environment
+	values    map[string]LoxValue
+	enclosing *Environment
+	imports   map[string]bool
 }
 
 func (env *Environment) init() {
@@ -15,7 +15,6 @@ func (env *Environment) init() {
 	environment object.
 	*/
 	env.values = map[string]LoxValue{}
-	env.constValues = map[string]bool{}
 }
 
 func (env *Environment) define(name string, value LoxValue) {
@@ -25,6 +24,22 @@ func (env *Environment) define(name string, value LoxValue) {
 	env.values[name] = value
 }
 
+func (env *Environment) markImported(path string) bool {
+	/*Remembers that the exports of the module
+	at the given path were defined in the
+	environment. Returns false if they
+	already were.
+	*/
+	if env.imports == nil {
+		env.imports = map[string]bool{}
+	}
+	if env.imports[path] {
+		return false
+	}
+	env.imports[path] = true
+	return true
+}
+
 func (env *Environment) get(name Token) LoxValue {
 	/*Returns the value of values in the current
 	environment given name.
@@ -45,14 +60,6 @@ func (env *Environment) varExists(name Token) bool {
 	return inMap
 }
 
-func (env *Environment) isConst(name Token) bool {
-	/*Determines if a given variable is a constant
-	variable with respect to this environment.
-	*/
-	_, inMap := env.constValues[name.lexeme]
-	return inMap
-}
-
 func (env *Environment) assign(name Token, value LoxValue) {
 	/*Reassigns existing variables in
 	dictionary values with new value.
@@ -65,3 +72,29 @@ func (env *Environment) assign(name Token, value LoxValue) {
 		panic(LoxException{token: name, message: fmt.Sprintf("Undefined variable '%s'", name.lexeme)})
 	}
 }
+
+func (env *Environment) ancestor(distance int) *Environment {
+	/*Returns the environment found the
+	given number of steps up the chain
+	of enclosing environments.
+	*/
+	ancestorEnv := env
+	for i := 0; i < distance; i++ {
+		ancestorEnv = ancestorEnv.enclosing
+	}
+	return ancestorEnv
+}
+
+func (env *Environment) getAt(distance int, name string) LoxValue {
+	/*Returns the value of a variable in
+	the environment at the given distance.
+	*/
+	return env.ancestor(distance).values[name]
+}
+
+func (env *Environment) assignAt(distance int, name Token, value LoxValue) {
+	/*Reassigns a variable in the environment
+	at the given distance.
+	*/
+	env.ancestor(distance).values[name.lexeme] = value
+}
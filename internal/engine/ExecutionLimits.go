@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"context"
+	"errors"
+)
+
+const CONTEXT_CHECK_INTERVAL = 1024
+
+var ErrStepLimitExceeded = errors.New("step limit exceeded")
+
+type executionLimits struct {
+	ctx      context.Context
+	steps    int64
+	maxSteps int64
+}
+
+type ExecutionInterrupt struct {
+	cause   error
+	token   Token
+	located bool
+	stack   []CallFrame
+}
+
+func (limits *executionLimits) reset(ctx context.Context) {
+	/*Starts a new run of the interpreter
+	with a full step budget. The run stops
+	when the given context is done.
+	*/
+	limits.ctx = ctx
+	limits.steps = 0
+}
+
+func (interrupt ExecutionInterrupt) message() string {
+	/*Returns the message reported to the
+	user when the program is stopped.
+	*/
+	if interrupt.cause == context.DeadlineExceeded {
+		return "Execution timed out"
+	} else if interrupt.cause == context.Canceled {
+		return "Execution cancelled"
+	}
+	return "Step limit exceeded"
+}
+
+func (inter *Interpreter) step() {
+	/*Counts one step of the program and
+	stops it if the step budget is spent or
+	its context is done. The context is only
+	polled every few steps to keep the check
+	cheap.
+	*/
+	limits := inter.limits
+	limits.steps++
+	if limits.maxSteps > 0 && limits.steps > limits.maxSteps {
+		panic(ExecutionInterrupt{cause: ErrStepLimitExceeded})
+	}
+	if limits.steps%CONTEXT_CHECK_INTERVAL == 0 {
+		if err := limits.ctx.Err(); err != nil {
+			panic(ExecutionInterrupt{cause: err})
+		}
+	}
+}
+
+func (inter *Interpreter) locateInterrupt(token Token) {
+	/*Deferred by loops to point an interrupt
+	raised inside of them at their keyword.
+	Other panics are passed on untouched.
+	*/
+	r := recover()
+	if interrupt, isInterrupt := r.(ExecutionInterrupt); isInterrupt && !interrupt.located {
+		interrupt.token = token
+		interrupt.located = true
+		interrupt.stack = inter.copyCallStack()
+		panic(interrupt)
+	} else if r != nil {
+		panic(r)
+	}
+}
@@ -0,0 +1,243 @@
+package engine
+
+type Assign struct {
+	name Token
+	value Expr
+	depth *int
+}
+
+func (assignObj Assign) accept(visitor Interpreter) LoxValue {
+	return visitor.visitAssignExpr(assignObj)
+}
+
+func (assignObj Assign) resolve(resolver *Resolver) {
+	resolver.visitAssignExpr(assignObj)
+}
+
+type Binary struct {
+	left Expr
+	operator Token
+	right Expr
+}
+
+func (binaryObj Binary) accept(visitor Interpreter) LoxValue {
+	return visitor.visitBinaryExpr(binaryObj)
+}
+
+func (binaryObj Binary) resolve(resolver *Resolver) {
+	resolver.visitBinaryExpr(binaryObj)
+}
+
+type Call struct {
+	callee Expr
+	paren Token
+	arguments []Expr
+}
+
+func (callObj Call) accept(visitor Interpreter) LoxValue {
+	return visitor.visitCallExpr(callObj)
+}
+
+func (callObj Call) resolve(resolver *Resolver) {
+	resolver.visitCallExpr(callObj)
+}
+
+type Grouping struct {
+	expression Expr
+}
+
+func (groupingObj Grouping) accept(visitor Interpreter) LoxValue {
+	return visitor.visitGroupingExpr(groupingObj)
+}
+
+func (groupingObj Grouping) resolve(resolver *Resolver) {
+	resolver.visitGroupingExpr(groupingObj)
+}
+
+type Literal struct {
+	value LoxValue
+}
+
+func (literalObj Literal) accept(visitor Interpreter) LoxValue {
+	return visitor.visitLiteralExpr(literalObj)
+}
+
+func (literalObj Literal) resolve(resolver *Resolver) {
+	resolver.visitLiteralExpr(literalObj)
+}
+
+type Logical struct {
+	left Expr
+	operator Token
+	right Expr
+}
+
+func (logicalObj Logical) accept(visitor Interpreter) LoxValue {
+	return visitor.visitLogicalExpr(logicalObj)
+}
+
+func (logicalObj Logical) resolve(resolver *Resolver) {
+	resolver.visitLogicalExpr(logicalObj)
+}
+
+type Unary struct {
+	operator Token
+	right Expr
+}
+
+func (unaryObj Unary) accept(visitor Interpreter) LoxValue {
+	return visitor.visitUnaryExpr(unaryObj)
+}
+
+func (unaryObj Unary) resolve(resolver *Resolver) {
+	resolver.visitUnaryExpr(unaryObj)
+}
+
+type Variable struct {
+	name Token
+	depth *int
+}
+
+func (variableObj Variable) accept(visitor Interpreter) LoxValue {
+	return visitor.visitVariableExpr(variableObj)
+}
+
+func (variableObj Variable) resolve(resolver *Resolver) {
+	resolver.visitVariableExpr(variableObj)
+}
+
+type Get struct {
+	object Expr
+	name Token
+}
+
+func (getObj Get) accept(visitor Interpreter) LoxValue {
+	return visitor.visitGetExpr(getObj)
+}
+
+func (getObj Get) resolve(resolver *Resolver) {
+	resolver.visitGetExpr(getObj)
+}
+
+type Set struct {
+	object Expr
+	name Token
+	value Expr
+}
+
+func (setObj Set) accept(visitor Interpreter) LoxValue {
+	return visitor.visitSetExpr(setObj)
+}
+
+func (setObj Set) resolve(resolver *Resolver) {
+	resolver.visitSetExpr(setObj)
+}
+
+type This struct {
+	keyword Token
+	depth *int
+}
+
+func (thisObj This) accept(visitor Interpreter) LoxValue {
+	return visitor.visitThisExpr(thisObj)
+}
+
+func (thisObj This) resolve(resolver *Resolver) {
+	resolver.visitThisExpr(thisObj)
+}
+
+type Super struct {
+	keyword Token
+	method Token
+	depth *int
+}
+
+func (superObj Super) accept(visitor Interpreter) LoxValue {
+	return visitor.visitSuperExpr(superObj)
+}
+
+func (superObj Super) resolve(resolver *Resolver) {
+	resolver.visitSuperExpr(superObj)
+}
+
+type List struct {
+	bracket Token
+	elements []Expr
+}
+
+func (listObj List) accept(visitor Interpreter) LoxValue {
+	return visitor.visitListExpr(listObj)
+}
+
+func (listObj List) resolve(resolver *Resolver) {
+	resolver.visitListExpr(listObj)
+}
+
+type Dict struct {
+	brace Token
+	keys []Expr
+	values []Expr
+}
+
+func (dictObj Dict) accept(visitor Interpreter) LoxValue {
+	return visitor.visitDictExpr(dictObj)
+}
+
+func (dictObj Dict) resolve(resolver *Resolver) {
+	resolver.visitDictExpr(dictObj)
+}
+
+type Lambda struct {
+	declaration Function
+}
+
+func (lambdaObj Lambda) accept(visitor Interpreter) LoxValue {
+	return visitor.visitLambdaExpr(lambdaObj)
+}
+
+func (lambdaObj Lambda) resolve(resolver *Resolver) {
+	resolver.visitLambdaExpr(lambdaObj)
+}
+
+type Interpolation struct {
+	quote Token
+	parts []Expr
+}
+
+func (interpolationObj Interpolation) accept(visitor Interpreter) LoxValue {
+	return visitor.visitInterpolationExpr(interpolationObj)
+}
+
+func (interpolationObj Interpolation) resolve(resolver *Resolver) {
+	resolver.visitInterpolationExpr(interpolationObj)
+}
+
+type Index struct {
+	object Expr
+	bracket Token
+	index Expr
+}
+
+func (indexObj Index) accept(visitor Interpreter) LoxValue {
+	return visitor.visitIndexExpr(indexObj)
+}
+
+func (indexObj Index) resolve(resolver *Resolver) {
+	resolver.visitIndexExpr(indexObj)
+}
+
+type IndexSet struct {
+	object Expr
+	bracket Token
+	index Expr
+	value Expr
+}
+
+func (indexsetObj IndexSet) accept(visitor Interpreter) LoxValue {
+	return visitor.visitIndexSetExpr(indexsetObj)
+}
+
+func (indexsetObj IndexSet) resolve(resolver *Resolver) {
+	resolver.visitIndexSetExpr(indexsetObj)
+}
+
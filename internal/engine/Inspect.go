@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+func Keywords() []string {
+	/*Returns the reserved words of
+	the language in alphabetical order.
+	*/
+	keywords := make([]string, 0, len(reservedWords))
+	for word := range reservedWords {
+		keywords = append(keywords, word)
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
+func FormatTokens(srcCode string) (string, error) {
+	/*Scans the given source code and returns
+	its tokens, one per line, with their type,
+	lexeme and position.
+	*/
+	tokenArr, scnrErrors := runLexer(&Source{name: REPL_SOURCE, text: srcCode})
+	if len(scnrErrors) > 0 {
+		return "", &CompileError{Diagnostics: scnrErrors}
+	}
+
+	lines := make([]string, len(tokenArr))
+	for i := 0; i < len(tokenArr); i++ {
+		token := tokenArr[i]
+		lines[i] = fmt.Sprintf("%d:%d %s %s", token.line, token.column, token.tokenType, token.lexeme)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func FormatAST(srcCode string) (string, error) {
+	/*Parses the given source code with the
+	rules of the REPL and returns the syntax
+	tree of every statement, one per line.
+	*/
+	tokenArr, scnrErrors := runLexer(&Source{name: REPL_SOURCE, text: srcCode})
+	if len(scnrErrors) > 0 {
+		return "", &CompileError{Diagnostics: scnrErrors}
+	}
+	stmtArr, parserErrors := runParser(tokenArr, true)
+	if len(parserErrors) > 0 {
+		return "", &CompileError{Diagnostics: parserErrors}
+	}
+
+	lines := make([]string, len(stmtArr))
+	for i := 0; i < len(stmtArr); i++ {
+		lines[i] = formatNode(reflect.ValueOf(stmtArr[i]))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func formatNode(node reflect.Value) string {
+	/*Returns a syntax tree node as a
+	parenthesized expression with the name
+	of the node followed by its fields.
+	Tokens are shown by their lexeme and
+	resolver slots are left out.
+	*/
+	tokenType := reflect.TypeOf(Token{})
+
+	switch node.Kind() {
+	case reflect.Interface:
+		if node.IsNil() {
+			return "nil"
+		}
+		return formatNode(node.Elem())
+	case reflect.Ptr:
+		return ""
+	case reflect.Slice:
+		elements := []string{}
+		for i := 0; i < node.Len(); i++ {
+			elements = append(elements, formatNode(node.Index(i)))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(elements, " "))
+	case reflect.Struct:
+		if node.Type() == tokenType {
+			return node.FieldByName("lexeme").String()
+		}
+		fields := []string{node.Type().Name()}
+		for i := 0; i < node.NumField(); i++ {
+			if field := formatNode(node.Field(i)); field != "" {
+				fields = append(fields, field)
+			}
+		}
+		return fmt.Sprintf("(%s)", strings.Join(fields, " "))
+	case reflect.Bool:
+		return fmt.Sprintf("%t", node.Bool())
+	case reflect.Int, reflect.Int64:
+		return fmt.Sprintf("%d", node.Int())
+	case reflect.Float64:
+		return fmt.Sprintf("%f", node.Float())
+	case reflect.String:
+		return fmt.Sprintf("%q", node.String())
+	}
+	return ""
+}
@@ -0,0 +1,1282 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type Interpreter struct {
+	trees           []Stmt
+	env             *Environment
+	globals         *Environment
+	scriptPath      string
+	modules         map[string]*LoxModule
+	importStack     []string
+	natives         []*NativeFunction
+	callStack       []CallFrame
+	maxCallDepth    int
+	limits          *executionLimits
+	capabilities    map[Capability]bool
+	stdin           *bufio.Reader
+	stdout          io.Writer
+	stderr          io.Writer
+	echo            bool
+	redefineGlobals bool
+}
+
+func (inter *Interpreter) init(stmtArr []Stmt) {
+	/*Initializes a interpreter
+	object.
+	*/
+	var env Environment
+	env.init()
+	inter.trees = stmtArr
+	inter.env = &env
+	inter.globals = &env
+	inter.scriptPath = ""
+	inter.modules = map[string]*LoxModule{}
+	inter.importStack = []string{}
+	inter.natives = standardLibrary()
+	inter.maxCallDepth = DEFAULT_MAX_CALL_DEPTH
+	inter.limits = &executionLimits{ctx: context.Background()}
+	inter.capabilities = capabilitySet(profiles[FULL_PROFILE])
+	inter.stdin = bufio.NewReader(os.Stdin)
+	inter.stdout = os.Stdout
+	inter.stderr = os.Stderr
+	inter.defineNatives(inter.globals)
+}
+
+func (inter *Interpreter) registerNative(native *NativeFunction) {
+	/*Adds a native function to the
+	interpreter. It is defined in the global
+	environment and in every module imported
+	afterwards.
+	*/
+	inter.natives = append(inter.natives, native)
+	inter.globals.define(native.name, native)
+}
+
+func (inter *Interpreter) setScriptPath(path string) {
+	/*Sets the path of the file being
+	interpreted. Imports are resolved
+	relative to this file.
+	*/
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+	inter.scriptPath = absPath
+	inter.importStack = []string{absPath}
+}
+
+func (inter *Interpreter) defineNatives(env *Environment) {
+	/*Defines the built-in functions
+	in the given environment.
+	*/
+	for i := 0; i < len(inter.natives); i++ {
+		env.define(inter.natives[i].name, inter.natives[i])
+	}
+}
+
+func (inter *Interpreter) interpret() (err error) {
+	/*Interprets the abstract syntax
+	trees passed in the initialized of
+	the interpreter. Returns a RuntimeError
+	if the program fails.
+	*/
+	defer func() {
+		if r := recover(); r != nil {
+			if interrupt, isInterrupt := r.(ExecutionInterrupt); isInterrupt {
+				err = inter.interruptError(interrupt)
+				return
+			}
+			if thrown, isThrow := r.(LoxThrow); isThrow {
+				r = inter.uncaughtException(thrown)
+			}
+			exc, isException := r.(LoxException)
+			if !isException {
+				panic(r)
+			}
+			err = newRuntimeError(tokenDiagnostic(RUNTIME_ERROR, exc.token, exc.message), exc.stack, nil)
+		}
+	}()
+	for i := 0; i < len(inter.trees); i++ {
+		if exprStmt, isExpr := inter.trees[i].(Expression); isExpr && inter.echo {
+			inter.echoValue(inter.evaluate(exprStmt.expression))
+		} else {
+			inter.execute(inter.trees[i])
+		}
+	}
+	return nil
+}
+
+func (inter *Interpreter) echoValue(value LoxValue) {
+	/*Prints the value of a top level
+	expression statement in the REPL.
+	Nil values are not printed.
+	*/
+	if value != nil {
+		fmt.Fprintln(inter.stdout, inter.stringify(value))
+	}
+}
+
+func (inter *Interpreter) interruptError(interrupt ExecutionInterrupt) *RuntimeError {
+	/*Converts an interrupt into the runtime
+	error returned to the user. Interrupts
+	raised outside of loops and calls have
+	no position in the code.
+	*/
+	diagnostic := Diagnostic{Kind: RUNTIME_ERROR, Message: interrupt.message()}
+	if interrupt.located {
+		diagnostic = tokenDiagnostic(RUNTIME_ERROR, interrupt.token, interrupt.message())
+	}
+	return newRuntimeError(diagnostic, interrupt.stack, interrupt.cause)
+}
+
+func (inter *Interpreter) uncaughtException(thrown LoxThrow) LoxException {
+	/*Converts a thrown value that was
+	never caught into the runtime error
+	reported to the user.
+	*/
+	if errValue, isError := thrown.value.(*ErrorValue); isError {
+		stack := errValue.stack
+		if stack == nil {
+			stack = thrown.stack
+		}
+		return LoxException{token: errValue.token, message: errValue.message, stack: stack}
+	}
+	return LoxException{token: thrown.token, message: fmt.Sprintf("Uncaught exception: %s", inter.stringify(thrown.value)), stack: thrown.stack}
+}
+
+func (inter *Interpreter) execute(stmt Stmt) {
+	/*Executes given statement.
+	 */
+	inter.step()
+	stmt.accept(*inter)
+}
+
+func (inter *Interpreter) evaluate(expr Expr) LoxValue {
+	/*Evaluates given expression.
+	 */
+	inter.step()
+	return expr.accept(*inter)
+}
+
+func (inter *Interpreter) stringify(value LoxValue) string {
+	/*Returns a human readable string representing
+	the result of the interpretation.
+	*/
+	return inter.stringifyNested(value, map[LoxValue]bool{})
+}
+
+func (inter *Interpreter) stringifyElement(value LoxValue, printing map[LoxValue]bool) string {
+	/*Returns the string representing a value
+	inside a list or dictionary. Strings are
+	quoted so they can't be mistaken for other
+	values or for several elements.
+	*/
+	if str, isString := value.(string); isString {
+		return strconv.Quote(str)
+	}
+	return inter.stringifyNested(value, printing)
+}
+
+func (inter *Interpreter) stringifyNested(value LoxValue, printing map[LoxValue]bool) string {
+	/*Returns the string representing the
+	given value. Containers being printed are
+	kept in printing so a container found
+	inside itself is shown as [...] or {...}
+	instead of being printed forever.
+	*/
+	if value == nil {
+		return "nil"
+	} else if inter.isInt(value) {
+		return fmt.Sprintf("%d", value.(int64))
+	} else if inter.isFloat(value) {
+		return fmt.Sprintf("%f", value.(float64))
+	} else if boolean, isBool := value.(bool); isBool {
+		return strconv.FormatBool(boolean)
+	} else if _, isString := value.(string); isString {
+		return value.(string)
+	} else if list, isList := value.(*LoxList); isList {
+		if printing[list] {
+			return "[...]"
+		}
+		printing[list] = true
+		defer delete(printing, list)
+		elements := make([]string, len(list.elements))
+		for i := 0; i < len(list.elements); i++ {
+			elements[i] = inter.stringifyElement(list.elements[i], printing)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(elements, ", "))
+	} else if dict, isDict := value.(*LoxDict); isDict {
+		if printing[dict] {
+			return "{...}"
+		}
+		printing[dict] = true
+		defer delete(printing, dict)
+		entries := make([]string, len(dict.keys))
+		for i := 0; i < len(dict.keys); i++ {
+			key := dict.keys[i]
+			entries[i] = fmt.Sprintf("%s: %s", inter.stringifyElement(key, printing), inter.stringifyElement(dict.entries[key], printing))
+		}
+		return fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+	} else {
+		if inter.isNativeFunction(value) {
+			function := value.(*NativeFunction)
+			return function.String()
+		} else if inter.isLoxModule(value) {
+			module := value.(*LoxModule)
+			return module.String()
+		} else if inter.isErrorValue(value) {
+			errValue := value.(*ErrorValue)
+			return errValue.String()
+		} else if inter.isLoxClass(value) {
+			class := value.(*LoxClass)
+			return class.String()
+		} else if inter.isLoxInstance(value) {
+			instance := value.(*LoxInstance)
+			return instance.String()
+		} else {
+			function := value.(LoxFunction)
+			return function.String()
+		}
+	}
+}
+
+func (inter *Interpreter) visitVarStmt(stmt Var) Stmt {
+	/*Returns the evaluation of
+	the variable declaration statement.
+	*/
+	var value LoxValue
+	inter.checkRedeclaration(stmt.name.lexeme, stmt.name)
+	if stmt.initializer != nil {
+		value = inter.evaluate(stmt.initializer)
+	}
+	inter.env.define(stmt.name.lexeme, value)
+
+	return nil
+}
+
+func (inter *Interpreter) checkRedeclaration(name string, errToken Token) {
+	/*Throws an exception pointing at errToken
+	if the name is already declared in the
+	current environment. When redefineGlobals
+	is set, declarations in the global environment
+	replace the old binding instead. Built-in
+	functions can always be shadowed by globals.
+	*/
+	if inter.redefineGlobals && inter.env == inter.globals {
+		return
+	}
+	if value, isDeclared := inter.env.values[name]; isDeclared {
+		if native, isNative := value.(*NativeFunction); isNative && native.name == name && inter.env == inter.globals {
+			return
+		}
+		panic(LoxException{token: errToken, message: fmt.Sprintf("Variable '%s' already exists.", name)})
+	}
+}
+
+func (inter *Interpreter) visitIfStmt(stmt If) Stmt {
+	/*Returns the evaluation of the if statement.
+	 */
+	if inter.isTruthy(inter.evaluate(stmt.condition)) {
+		inter.execute(stmt.thenBranch)
+	} else if stmt.elseBranch != nil {
+		inter.execute(stmt.elseBranch)
+	}
+
+	return nil
+}
+
+func (inter *Interpreter) visitExpressionStmt(stmt Expression) Stmt {
+	/*Returns the evaluation of
+	the expression statement.
+	*/
+	inter.evaluate(stmt.expression)
+
+	return nil
+}
+
+func (inter *Interpreter) visitPrintStmt(stmt Print) Stmt {
+	/*Returns the evaluation of
+	the print statement.
+	*/
+	value := inter.evaluate(stmt.expression)
+	fmt.Fprintln(inter.stdout, inter.stringify(value))
+
+	return nil
+}
+
+func (inter *Interpreter) visitWhileStmt(stmt While) Stmt {
+	/*Returns the evaluation of
+	a while statement. Every iteration
+	counts as a step of the program.
+	*/
+	defer inter.locateInterrupt(stmt.keyword)
+	for inter.isTruthy(inter.evaluate(stmt.condition)) {
+		inter.step()
+		if inter.executeLoopBody(stmt.body) {
+			break
+		}
+		if stmt.increment != nil {
+			inter.evaluate(stmt.increment)
+		}
+	}
+
+	return nil
+}
+
+func (inter *Interpreter) executeLoopBody(body Stmt) (isBreak bool) {
+	/*Executes one iteration of a loop body.
+	Returns true if the body executed a
+	break statement.
+	*/
+	defer func() {
+		r := recover()
+		if r != nil {
+			if _, isBreakSignal := r.(RuntimeBreak); isBreakSignal {
+				isBreak = true
+			} else if _, isContinueSignal := r.(RuntimeContinue); !isContinueSignal {
+				panic(r)
+			}
+		}
+	}()
+	inter.execute(body)
+	return false
+}
+
+func (inter *Interpreter) visitBreakStmt(stmt Break) Stmt {
+	/*Executes a break statement by raising
+	an exception caught by the enclosing loop.
+	*/
+	panic(RuntimeBreak{keyword: stmt.keyword})
+}
+
+func (inter *Interpreter) visitContinueStmt(stmt Continue) Stmt {
+	/*Executes a continue statement by raising
+	an exception caught by the enclosing loop.
+	*/
+	panic(RuntimeContinue{keyword: stmt.keyword})
+}
+
+func (inter *Interpreter) visitBlockStmt(stmt Block) Stmt {
+	/*Calls executeBlock method to
+	execute all statements within
+	the block.
+	*/
+	var blockEnv Environment
+	blockEnv.init()
+	blockEnv.enclosing = inter.env
+	inter.executeBlock(stmt.statements, &blockEnv)
+
+	return nil
+}
+
+func (inter *Interpreter) executeBlock(statements []Stmt, env *Environment) {
+	/*Executes all statements
+	within the block.
+	*/
+	previousEnv := inter.env
+
+	defer func() {
+		inter.env = previousEnv
+	}()
+	inter.env = env
+	for i := 0; i < len(statements); i++ {
+		inter.execute(statements[i])
+	}
+}
+
+func (inter *Interpreter) visitFunctionStmt(stmt Function) Stmt {
+	/*Creates a LoxFunction object and
+	defines an environment.
+	*/
+	function := LoxFunction{declaration: stmt, closure: inter.env, globals: inter.globals}
+	inter.env.define(stmt.name.lexeme, function)
+
+	return nil
+}
+
+func (inter *Interpreter) visitImportStmt(stmt Import) Stmt {
+	/*Imports a module. Named imports define
+	the module itself while plain imports
+	define every exported binding of the
+	module in the current environment. Plain
+	imports copy the values the bindings have
+	when they are imported, so later assignments
+	inside the module are only seen through a
+	named import. Importing the same module
+	again without a name does nothing.
+	*/
+	module := inter.loadModule(stmt.path)
+
+	if stmt.hasName {
+		inter.checkRedeclaration(stmt.name.lexeme, stmt.name)
+		inter.env.define(stmt.name.lexeme, module)
+		return nil
+	}
+
+	if !inter.env.markImported(module.path) {
+		return nil
+	}
+	for i := 0; i < len(module.exports); i++ {
+		name := module.exports[i].name
+		inter.checkRedeclaration(name, stmt.path)
+		inter.env.define(name, module.env.values[name])
+	}
+	return nil
+}
+
+func (inter *Interpreter) loadModule(pathToken Token) *LoxModule {
+	/*Returns the module found at the given
+	path. A module is scanned, parsed and
+	executed in its own environment the first
+	time it is imported and cached afterwards.
+	Reading modules needs the filesystem
+	capability.
+	*/
+	if !inter.capabilities[FILESYSTEM_CAPABILITY] {
+		panic(LoxException{token: pathToken, message: deniedMessage(FILESYSTEM_CAPABILITY, fmt.Sprintf("import '%s'", pathToken.literal))})
+	}
+	path, err := findModule(inter.scriptPath, pathToken.literal.(string))
+	if err != nil {
+		panic(LoxException{token: pathToken, message: err.Error()})
+	}
+
+	for i := 0; i < len(inter.importStack); i++ {
+		if inter.importStack[i] == path {
+			var cycle []string
+			for ii := i; ii < len(inter.importStack); ii++ {
+				cycle = append(cycle, filepath.Base(inter.importStack[ii]))
+			}
+			cycle = append(cycle, filepath.Base(path))
+			panic(LoxException{token: pathToken, message: fmt.Sprintf("Import cycle detected: %s", strings.Join(cycle, " -> "))})
+		}
+	}
+	if module, isCached := inter.modules[path]; isCached {
+		return module
+	}
+
+	srcCode, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(LoxException{token: pathToken, message: fmt.Sprintf("Failed opening module '%s'", path)})
+	}
+	var resolver Resolver
+	resolver.init()
+	resolver.scriptPath = path
+	resolver.readModules = true
+	stmtArr, _, err := compile(resolver, &Source{name: getDisplayPath(path), text: string(srcCode)}, false)
+	if err != nil {
+		first := err.(*CompileError).Diagnostics[0]
+		panic(LoxException{token: pathToken, message: fmt.Sprintf("Failed to compile module '%s' (%s:%d:%d: %s)", getDisplayPath(path), first.File, first.Line, first.Column, first.Message)})
+	}
+
+	var moduleEnv Environment
+	moduleEnv.init()
+	inter.defineNatives(&moduleEnv)
+
+	moduleInter := *inter
+	moduleInter.env = &moduleEnv
+	moduleInter.globals = &moduleEnv
+	moduleInter.scriptPath = path
+	moduleInter.redefineGlobals = false
+	moduleInter.importStack = append(append([]string{}, inter.importStack...), path)
+	for i := 0; i < len(stmtArr); i++ {
+		moduleInter.execute(stmtArr[i])
+	}
+
+	module := &LoxModule{name: getModuleName(path), path: path, env: &moduleEnv, exports: getExports(stmtArr)}
+	inter.modules[path] = module
+	return module
+}
+
+func (inter *Interpreter) visitThrowStmt(stmt Throw) Stmt {
+	/*Executes a throw statement by raising
+	an exception with the thrown value
+	attached to it.
+	*/
+	value := inter.evaluate(stmt.value)
+	panic(LoxThrow{value: value, token: stmt.keyword})
+}
+
+func (inter *Interpreter) visitTryStmt(stmt Try) Stmt {
+	/*Executes a try statement. Runtime errors
+	and thrown values raised in the try block
+	are passed to the catch block. The finally
+	block always runs last, unless the
+	program was interrupted.
+	*/
+	defer func() {
+		r := recover()
+		if _, isInterrupt := r.(ExecutionInterrupt); isInterrupt {
+			panic(r)
+		}
+		var finallyEnv Environment
+		finallyEnv.init()
+		finallyEnv.enclosing = inter.env
+		inter.executeBlock(stmt.finallyBody, &finallyEnv)
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	var tryEnv Environment
+	tryEnv.init()
+	tryEnv.enclosing = inter.env
+	if !stmt.hasCatch {
+		inter.executeBlock(stmt.body, &tryEnv)
+		return nil
+	}
+
+	if caught, isCaught := inter.executeTryBlock(stmt.body, &tryEnv); isCaught {
+		var catchEnv Environment
+		catchEnv.init()
+		catchEnv.enclosing = inter.env
+		catchEnv.define(stmt.catchName.lexeme, caught)
+		inter.executeBlock(stmt.catchBody, &catchEnv)
+	}
+
+	return nil
+}
+
+func (inter *Interpreter) executeTryBlock(statements []Stmt, env *Environment) (caught LoxValue, isCaught bool) {
+	/*Executes the statements of a try block.
+	Returns the caught value and true if a
+	runtime error or thrown value was raised.
+	*/
+	defer func() {
+		r := recover()
+		if r != nil {
+			if exc, isLoxException := r.(LoxException); isLoxException {
+				stack := exc.stack
+				if stack == nil {
+					stack = inter.copyCallStack()
+				}
+				caught = &ErrorValue{message: exc.message, line: exc.token.line, token: exc.token, stack: stack}
+				isCaught = true
+			} else if thrown, isThrow := r.(LoxThrow); isThrow {
+				caught = thrown.value
+				isCaught = true
+			} else {
+				panic(r)
+			}
+		}
+	}()
+	inter.executeBlock(statements, env)
+	return nil, false
+}
+
+func (inter *Interpreter) visitClassStmt(stmt Class) Stmt {
+	/*Creates a LoxClass object with its
+	methods and defines it in the current
+	environment.
+	*/
+	inter.checkRedeclaration(stmt.name.lexeme, stmt.name)
+	var superclass *LoxClass = nil
+	if stmt.superclass != nil {
+		superValue, isClass := inter.evaluate(stmt.superclass).(*LoxClass)
+		if !isClass {
+			panic(LoxException{token: stmt.superclass.(Variable).name, message: "Superclass must be a class"})
+		}
+		superclass = superValue
+	}
+
+	methodEnv := inter.env
+	if superclass != nil {
+		var superEnv Environment
+		superEnv.init()
+		superEnv.enclosing = inter.env
+		superEnv.define("super", superclass)
+		methodEnv = &superEnv
+	}
+
+	methods := map[string]LoxFunction{}
+	for i := 0; i < len(stmt.methods); i++ {
+		method := stmt.methods[i]
+		methods[method.name.lexeme] = LoxFunction{declaration: method, closure: methodEnv, globals: inter.globals, isInitializer: method.name.lexeme == "init"}
+	}
+	inter.env.define(stmt.name.lexeme, &LoxClass{name: stmt.name.lexeme, line: stmt.name.line, superclass: superclass, methods: methods})
+
+	return nil
+}
+
+func (inter *Interpreter) visitReturnStmt(stmt Return) Stmt {
+	/*Executes the return statement by
+	raising an exception with a
+	Return object attached to it.
+	*/
+	var value LoxValue
+	if stmt.value != nil {
+		value = inter.evaluate(stmt.value)
+	}
+	panic(RuntimeReturn{value: value})
+}
+
+func (inter *Interpreter) visitAssignExpr(expr Assign) LoxValue {
+	/*Returns the evaluation of an assignment
+	expression.
+	*/
+	value := inter.evaluate(expr.value)
+	if *expr.depth == GLOBAL_DEPTH {
+		inter.globals.assign(expr.name, value)
+	} else {
+		inter.env.assignAt(*expr.depth, expr.name, value)
+	}
+	return value
+}
+
+func (inter *Interpreter) visitBinaryExpr(expr Binary) LoxValue {
+	/*Returns the representation of a binary
+	expression.
+	*/
+	left := inter.evaluate(expr.left)
+	right := inter.evaluate(expr.right)
+
+	switch expr.operator.tokenType {
+	case MOD:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			result := math.Mod(inter.convertNumToFloat(left), inter.convertNumToFloat(right))
+			if inter.isInt(left) && inter.isInt(right) {
+				return int64(result)
+			} else {
+				return result
+			}
+		}
+	case MINUS:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			if inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) - right.(int64)
+			} else {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat - rightFloat
+			}
+		}
+	case SLASH:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			if (math.Mod(inter.convertNumToFloat(left), inter.convertNumToFloat(right)) == 0) && inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) / right.(int64)
+			} else {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat / rightFloat
+			}
+		}
+	case STAR:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			if inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) * right.(int64)
+			} else {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat * rightFloat
+			}
+		}
+	case PLUS:
+		{
+			if inter.isString(left) && inter.isString(right) {
+				return fmt.Sprintf("%s%s", left.(string), right.(string))
+			} else if inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) + right.(int64)
+			} else if inter.isNumber(left) && inter.isNumber(right) {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat + rightFloat
+			} else {
+				panic(LoxException{token: expr.operator, message: "Operands must be two numbers or two strings."})
+			}
+		}
+	case GREATER_THAN:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			if inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) > right.(int64)
+			} else {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat > rightFloat
+			}
+		}
+	case GREATER_EQUAL:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			if inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) >= right.(int64)
+			} else {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat >= rightFloat
+			}
+		}
+	case LESS_THAN:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			if inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) < right.(int64)
+			} else {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat < rightFloat
+			}
+		}
+	case LESS_EQUAL:
+		{
+			inter.checkNumberOperands(expr.operator, left, right)
+			if inter.isInt(left) && inter.isInt(right) {
+				return left.(int64) <= right.(int64)
+			} else {
+				leftFloat, rightFloat := inter.convertNumsToFloat(left, right)
+				return leftFloat <= rightFloat
+			}
+		}
+	case NOT_EQUAL:
+		{
+			return !inter.isEqual(left, right)
+		}
+	case EQUAL_EQUAL:
+		{
+			return inter.isEqual(left, right)
+		}
+	default:
+		return nil
+	}
+}
+
+func (inter *Interpreter) visitCallExpr(expr Call) LoxValue {
+	/*Evaluates a call expression.
+	 */
+	callee := inter.evaluate(expr.callee)
+
+	var arguments []LoxValue
+	for i := 0; i < len(expr.arguments); i++ {
+		arguments = append(arguments, inter.evaluate(expr.arguments[i]))
+	}
+	if _, isLoxCallable := callee.(LoxCallable); !isLoxCallable {
+		panic(LoxException{token: expr.paren, message: "Can only call functions"})
+	}
+
+	defer func() {
+		r := recover()
+		if r != nil {
+			if functionErr, isFuncError := r.(FunctionException); isFuncError {
+				panic(LoxException{token: expr.paren, message: functionErr.message, stack: inter.copyCallStack()})
+			} else if interrupt, isInterrupt := r.(ExecutionInterrupt); isInterrupt && !interrupt.located {
+				interrupt.token = expr.paren
+				interrupt.located = true
+				interrupt.stack = inter.copyCallStack()
+				panic(interrupt)
+			} else if exc, isLoxException := r.(LoxException); isLoxException && exc.stack == nil {
+				exc.stack = inter.copyCallStack()
+				panic(exc)
+			} else if thrown, isThrow := r.(LoxThrow); isThrow && thrown.stack == nil {
+				thrown.stack = inter.copyCallStack()
+				panic(thrown)
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	function := callee.(LoxCallable)
+	if function.arity() != VARIADIC_ARITY && len(arguments) != function.arity() {
+		panic(LoxException{token: expr.paren, message: fmt.Sprintf("Expected %d arguments but got %d", function.arity(), len(arguments))})
+	}
+	inter.callStack = append(inter.callStack, inter.callFrame(function, expr.paren))
+	if inter.maxCallDepth > 0 && len(inter.callStack) > inter.maxCallDepth {
+		panic(LoxException{token: expr.paren, message: "Stack overflow", stack: inter.copyCallStack()})
+	}
+	return function.call(*inter, arguments)
+}
+
+func (inter *Interpreter) callFrame(function LoxCallable, paren Token) CallFrame {
+	/*Returns the frame pushed onto the call
+	stack when the given function is called
+	at the given parenthesis.
+	*/
+	frame := CallFrame{Function: "<unknown>", Line: paren.line}
+	if paren.source != nil {
+		frame.File = paren.source.name
+	}
+
+	if loxFunc, isFunc := function.(LoxFunction); isFunc {
+		frame.Function = loxFunc.declaration.name.lexeme
+		if loxFunc.isAnonymous() {
+			frame.Function = "anonymous"
+		}
+		frame.DefinitionLine = loxFunc.declaration.name.line
+	} else if class, isClass := function.(*LoxClass); isClass {
+		frame.Function = class.name
+		frame.DefinitionLine = class.line
+		if initializer, hasInit := class.findMethod("init"); hasInit {
+			frame.Function = class.name + ".init"
+			frame.DefinitionLine = initializer.declaration.name.line
+		}
+	} else if native, isNative := function.(*NativeFunction); isNative {
+		frame.Function = native.name
+	}
+	return frame
+}
+
+func (inter *Interpreter) copyCallStack() []CallFrame {
+	/*Returns a copy of the current call
+	stack, outermost call first.
+	*/
+	stack := make([]CallFrame, len(inter.callStack))
+	copy(stack, inter.callStack)
+	return stack
+}
+
+func (inter *Interpreter) visitGetExpr(expr Get) LoxValue {
+	/*Returns the value of a property
+	of an instance.
+	*/
+	object := inter.evaluate(expr.object)
+	if instance, isInstance := object.(*LoxInstance); isInstance {
+		return instance.get(expr.name)
+	}
+	if errValue, isError := object.(*ErrorValue); isError {
+		return errValue.get(expr.name)
+	}
+	if module, isModule := object.(*LoxModule); isModule {
+		return module.get(expr.name)
+	}
+
+	panic(LoxException{token: expr.name, message: "Only instances have properties"})
+}
+
+func (inter *Interpreter) visitSetExpr(expr Set) LoxValue {
+	/*Evaluates the assignment of a field
+	of an instance.
+	*/
+	object := inter.evaluate(expr.object)
+	instance, isInstance := object.(*LoxInstance)
+	if !isInstance {
+		panic(LoxException{token: expr.name, message: "Only instances have fields"})
+	}
+
+	value := inter.evaluate(expr.value)
+	instance.set(expr.name, value)
+	return value
+}
+
+func (inter *Interpreter) visitThisExpr(expr This) LoxValue {
+	/*Returns the instance bound to
+	the current method.
+	*/
+	return inter.lookUpVariable(expr.keyword, expr.depth)
+}
+
+func (inter *Interpreter) visitSuperExpr(expr Super) LoxValue {
+	/*Returns the superclass method bound
+	to the instance of the current method.
+	*/
+	superclass := inter.env.getAt(*expr.depth, "super").(*LoxClass)
+	instance := inter.env.getAt(*expr.depth-1, "this").(*LoxInstance)
+
+	method, hasMethod := superclass.findMethod(expr.method.lexeme)
+	if !hasMethod {
+		panic(LoxException{token: expr.method, message: fmt.Sprintf("Undefined property '%s'", expr.method.lexeme)})
+	}
+	return method.bind(instance)
+}
+
+func (inter *Interpreter) visitLambdaExpr(expr Lambda) LoxValue {
+	/*Creates a LoxFunction object that
+	closes over the current environment.
+	*/
+	return LoxFunction{declaration: expr.declaration, closure: inter.env, globals: inter.globals}
+}
+
+func (inter *Interpreter) visitInterpolationExpr(expr Interpolation) LoxValue {
+	/*Evaluates every part of an interpolated
+	string and joins their string
+	representations.
+	*/
+	var result strings.Builder
+	for i := 0; i < len(expr.parts); i++ {
+		result.WriteString(inter.stringify(inter.evaluate(expr.parts[i])))
+	}
+	return result.String()
+}
+
+func (inter *Interpreter) visitListExpr(expr List) LoxValue {
+	/*Evaluates the elements of a list
+	literal and returns a new list.
+	*/
+	elements := make([]LoxValue, len(expr.elements))
+	for i := 0; i < len(expr.elements); i++ {
+		elements[i] = inter.evaluate(expr.elements[i])
+	}
+	return &LoxList{elements: elements}
+}
+
+func (inter *Interpreter) visitDictExpr(expr Dict) LoxValue {
+	/*Evaluates the entries of a dictionary
+	literal and returns a new dictionary.
+	*/
+	var dict LoxDict
+	dict.init()
+	for i := 0; i < len(expr.keys); i++ {
+		key := inter.evaluate(expr.keys[i])
+		dict.set(expr.brace, key, inter.evaluate(expr.values[i]))
+	}
+	return &dict
+}
+
+func (inter *Interpreter) visitIndexExpr(expr Index) LoxValue {
+	/*Returns the element of a list found
+	at the given index or the value of a
+	dictionary found under the given key.
+	*/
+	object := inter.evaluate(expr.object)
+	index := inter.evaluate(expr.index)
+	if list, isList := object.(*LoxList); isList {
+		return list.get(expr.bracket, index)
+	} else if dict, isDict := object.(*LoxDict); isDict {
+		return dict.get(expr.bracket, index)
+	}
+
+	panic(LoxException{token: expr.bracket, message: "Only lists and dictionaries can be indexed"})
+}
+
+func (inter *Interpreter) visitIndexSetExpr(expr IndexSet) LoxValue {
+	/*Evaluates the assignment of an element
+	of a list or an entry of a dictionary.
+	*/
+	object := inter.evaluate(expr.object)
+	index := inter.evaluate(expr.index)
+	if !inter.isLoxList(object) && !inter.isLoxDict(object) {
+		panic(LoxException{token: expr.bracket, message: "Only lists and dictionaries can be indexed"})
+	}
+
+	value := inter.evaluate(expr.value)
+	if list, isList := object.(*LoxList); isList {
+		list.set(expr.bracket, index, value)
+	} else {
+		object.(*LoxDict).set(expr.bracket, index, value)
+	}
+	return value
+}
+
+func (inter *Interpreter) visitGroupingExpr(expr Grouping) LoxValue {
+	/*Returns the evaluation of the expression
+	enclosed in parenthesis.
+	*/
+	return inter.evaluate(expr.expression)
+}
+
+func (inter *Interpreter) visitLiteralExpr(expr Literal) LoxValue {
+	/*Returns the value of a literal expression.
+	 */
+	if inter.isInt(expr.value) {
+		return expr.value.(int64)
+	} else if inter.isFloat(expr.value) {
+		return expr.value.(float64)
+	} else {
+		return expr.value
+	}
+}
+
+func (inter *Interpreter) visitUnaryExpr(expr Unary) LoxValue {
+	/*Returns the evaluation of
+	a unary expression.
+	*/
+	right := inter.evaluate(expr.right)
+
+	if expr.operator.tokenType == MINUS {
+		inter.checkNumberOperand(expr.operator, right)
+		if inter.isInt(right) {
+			return -right.(int64)
+		} else {
+			return -right.(float64)
+		}
+	} else if expr.operator.tokenType == NOT {
+		return !inter.isTruthy(right)
+	} else {
+		return nil
+	}
+}
+
+func (inter *Interpreter) visitLogicalExpr(expr Logical) LoxValue {
+	/*Executes a logical expression.
+	 */
+	left := inter.evaluate(expr.left)
+
+	if expr.operator.tokenType == OR {
+		if inter.isTruthy(left) {
+			return left
+		}
+	} else if expr.operator.tokenType == AND {
+		if !inter.isTruthy(left) {
+			return left
+		}
+	}
+
+	return inter.evaluate(expr.right)
+}
+
+func (inter *Interpreter) visitVariableExpr(expr Variable) LoxValue {
+	/*Returns the evaluation of
+	the variable expression.
+	*/
+	return inter.lookUpVariable(expr.name, expr.depth)
+}
+
+func (inter *Interpreter) lookUpVariable(name Token, depth *int) LoxValue {
+	/*Returns the value of a variable using
+	the scope depth found by the resolver.
+	*/
+	if *depth == GLOBAL_DEPTH {
+		return inter.globals.get(name)
+	}
+	return inter.env.getAt(*depth, name.lexeme)
+}
+
+func (inter *Interpreter) isTruthy(obj LoxValue) bool {
+	/*Determines whether the object
+	is truthy or falsey.
+	*/
+	if obj == nil {
+		return false
+	} else if _, isBool := obj.(bool); isBool {
+		return obj.(bool)
+	} else {
+		return true
+	}
+}
+
+func (inter *Interpreter) checkNumberOperand(operator Token, operand LoxValue) {
+	/*Checks if operand is a number. Otherwise
+	it throws an exception.
+	*/
+	if !inter.isNumber(operand) {
+		panic(LoxException{token: operator, message: "Operand must be a number"})
+	}
+}
+
+func (inter *Interpreter) checkNumberOperands(operator Token, left LoxValue, right LoxValue) {
+	/*Checks if both left and right are
+	numbers, otherwise it throws an
+	exception.
+	*/
+	if !inter.isNumber(left) || !inter.isNumber(right) {
+		panic(LoxException{token: operator, message: "Operands must be numbers"})
+	}
+}
+
+func (inter *Interpreter) isNumber(value LoxValue) bool {
+	/*Checks if the value is either a float
+	or an int.
+	*/
+	return inter.isInt(value) || inter.isFloat(value)
+}
+
+func (inter *Interpreter) convertNumToInt(value LoxValue) int64 {
+	/*Converts a LoxValue that is a number
+	to a int64.
+	*/
+	if inter.isFloat(value) {
+		return int64(value.(float64))
+	} else {
+		return value.(int64)
+	}
+}
+
+func (inter *Interpreter) convertNumsToFloat(value1 LoxValue, value2 LoxValue) (float64, float64) {
+	/*Converts two LoxValues that are numbers
+	to float64 numbers.
+	*/
+	return inter.convertNumToFloat(value1), inter.convertNumToFloat(value2)
+}
+
+func (inter *Interpreter) convertNumToFloat(value LoxValue) float64 {
+	/*Converts a LoxValue that is a number
+	to a float64.
+	*/
+	if inter.isInt(value) {
+		return float64(value.(int64))
+	} else {
+		return value.(float64)
+	}
+}
+
+func (inter *Interpreter) isInt(value LoxValue) bool {
+	/*Determines if the passed number
+	is an integer or not.
+	*/
+	_, isInt := value.(int64)
+	return isInt
+}
+
+func (inter *Interpreter) isFloat(value LoxValue) bool {
+	/*Determines if a value is
+	a float number.
+	*/
+	_, isFloat := value.(float64)
+	return isFloat
+}
+
+func (inter *Interpreter) isString(value LoxValue) bool {
+	/*Determines if a value is
+	a string.
+	*/
+	_, isString := value.(string)
+	return isString
+}
+
+func (inter *Interpreter) isUserFunc(value LoxValue) bool {
+	/*Determines if a value is
+	a function.
+	*/
+	_, isFunc := value.(LoxFunction)
+	return isFunc
+}
+
+func (inter *Interpreter) isNativeFunction(value LoxValue) bool {
+	/*Determines if a value is
+	a built-in function.
+	*/
+	_, isNative := value.(*NativeFunction)
+	return isNative
+}
+
+func (inter *Interpreter) isLoxClass(value LoxValue) bool {
+	/*Determines if a value is
+	a class.
+	*/
+	_, isClass := value.(*LoxClass)
+	return isClass
+}
+
+func (inter *Interpreter) isLoxInstance(value LoxValue) bool {
+	/*Determines if a value is
+	an instance of a class.
+	*/
+	_, isInstance := value.(*LoxInstance)
+	return isInstance
+}
+
+func (inter *Interpreter) isLoxList(value LoxValue) bool {
+	/*Determines if a value is
+	a list.
+	*/
+	_, isList := value.(*LoxList)
+	return isList
+}
+
+func (inter *Interpreter) isLoxDict(value LoxValue) bool {
+	/*Determines if a value is
+	a dictionary.
+	*/
+	_, isDict := value.(*LoxDict)
+	return isDict
+}
+
+func (inter *Interpreter) isErrorValue(value LoxValue) bool {
+	/*Determines if a value is
+	a caught runtime error.
+	*/
+	_, isError := value.(*ErrorValue)
+	return isError
+}
+
+func (inter *Interpreter) isLoxModule(value LoxValue) bool {
+	/*Determines if a value is
+	an imported module.
+	*/
+	_, isModule := value.(*LoxModule)
+	return isModule
+}
+
+type comparedPair struct {
+	left  LoxValue
+	right LoxValue
+}
+
+func (inter *Interpreter) isEqual(left LoxValue, right LoxValue) bool {
+	/*Checks if left and right are
+	equal according to the rules
+	of the lox language.
+	*/
+	return inter.isEqualNested(left, right, map[comparedPair]bool{})
+}
+
+func (inter *Interpreter) isEqualNested(left LoxValue, right LoxValue, comparing map[comparedPair]bool) bool {
+	/*Checks if left and right are equal.
+	Containers being compared are kept in
+	comparing, a pair found again inside
+	itself is taken as equal instead of
+	being compared forever.
+	*/
+	if left == nil && right == nil {
+		return true
+	} else if left == nil {
+		return false
+	} else if inter.isInt(left) && inter.isInt(right) {
+		return left.(int64) == right.(int64)
+	} else if inter.isString(left) && inter.isString(right) {
+		return left.(string) == right.(string)
+	} else if inter.isNumber(left) && inter.isNumber(right) {
+		return inter.convertNumToFloat(left) == inter.convertNumToFloat(right)
+	} else if inter.isUserFunc(left) && inter.isUserFunc(right) {
+		leftFunc, rightFunc := left.(LoxFunction), right.(LoxFunction)
+		if leftFunc.isAnonymous() || rightFunc.isAnonymous() {
+			return leftFunc.declaration.name == rightFunc.declaration.name && leftFunc.closure == rightFunc.closure
+		}
+		return leftFunc.declaration.name.lexeme == rightFunc.declaration.name.lexeme
+	} else if inter.isLoxList(left) && inter.isLoxList(right) {
+		leftList, rightList := left.(*LoxList), right.(*LoxList)
+		pair := comparedPair{left: leftList, right: rightList}
+		if leftList == rightList || comparing[pair] {
+			return true
+		}
+		if len(leftList.elements) != len(rightList.elements) {
+			return false
+		}
+		comparing[pair] = true
+		defer delete(comparing, pair)
+		for i := 0; i < len(leftList.elements); i++ {
+			if !inter.isEqualNested(leftList.elements[i], rightList.elements[i], comparing) {
+				return false
+			}
+		}
+		return true
+	} else if inter.isLoxDict(left) && inter.isLoxDict(right) {
+		leftDict, rightDict := left.(*LoxDict), right.(*LoxDict)
+		pair := comparedPair{left: leftDict, right: rightDict}
+		if leftDict == rightDict || comparing[pair] {
+			return true
+		}
+		if len(leftDict.keys) != len(rightDict.keys) {
+			return false
+		}
+		comparing[pair] = true
+		defer delete(comparing, pair)
+		for i := 0; i < len(leftDict.keys); i++ {
+			key := leftDict.keys[i]
+			rightValue, inRight := rightDict.entries[key]
+			if !inRight || !inter.isEqualNested(leftDict.entries[key], rightValue, comparing) {
+				return false
+			}
+		}
+		return true
+	} else if inter.isLoxClass(left) && inter.isLoxClass(right) {
+		return left.(*LoxClass) == right.(*LoxClass)
+	} else if inter.isLoxInstance(left) && inter.isLoxInstance(right) {
+		return left.(*LoxInstance) == right.(*LoxInstance)
+	} else if inter.isErrorValue(left) && inter.isErrorValue(right) {
+		return left.(*ErrorValue) == right.(*ErrorValue)
+	} else if inter.isLoxModule(left) && inter.isLoxModule(right) {
+		return left.(*LoxModule) == right.(*LoxModule)
+	} else if inter.isNativeFunction(left) && inter.isNativeFunction(right) {
+		return left.(*NativeFunction) == right.(*NativeFunction)
+	} else {
+		return false
+	}
+}
@@ -0,0 +1,56 @@
+package engine
+
+import "fmt"
+
+type LoxClass struct {
+	name       string
+	line       int
+	superclass *LoxClass
+	methods    map[string]LoxFunction
+}
+
+func (loxClass *LoxClass) call(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Implements the method call
+	from the interface LoxCallable.
+	Creates a new instance of the class
+	and runs its initializer if it has one.
+	*/
+	instance := &LoxInstance{class: loxClass, fields: map[string]LoxValue{}}
+	if initializer, hasInit := loxClass.findMethod("init"); hasInit {
+		initializer.bind(instance).call(interpreter, arguments)
+	}
+
+	return instance
+}
+
+func (loxClass *LoxClass) arity() int {
+	/*Determines the arity of the class
+	using the arity of its initializer.
+	*/
+	if initializer, hasInit := loxClass.findMethod("init"); hasInit {
+		return initializer.arity()
+	}
+	return 0
+}
+
+func (loxClass *LoxClass) findMethod(name string) (LoxFunction, bool) {
+	/*Returns the method with the given
+	name and whether it was found. Looks
+	up the superclass chain when the class
+	does not define the method itself.
+	*/
+	if method, inMap := loxClass.methods[name]; inMap {
+		return method, true
+	}
+	if loxClass.superclass != nil {
+		return loxClass.superclass.findMethod(name)
+	}
+	return LoxFunction{}, false
+}
+
+func (loxClass *LoxClass) String() string {
+	/*Returns a human readable string
+	representing the object LoxClass.
+	*/
+	return fmt.Sprintf("<class %s>", loxClass.name)
+}
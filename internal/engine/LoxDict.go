@@ -0,0 +1,111 @@
+package engine
+
+import "fmt"
+
+type LoxDict struct {
+	keys    []LoxValue
+	entries map[LoxValue]LoxValue
+}
+
+func (dict *LoxDict) init() {
+	/*Initializes an empty
+	dictionary.
+	*/
+	dict.keys = []LoxValue{}
+	dict.entries = map[LoxValue]LoxValue{}
+}
+
+func (dict *LoxDict) get(bracket Token, key LoxValue) LoxValue {
+	/*Returns the value stored under the
+	given key. Throws an exception if the
+	key is not in the dictionary.
+	*/
+	value, inDict := dict.entries[dict.checkKey(bracket, key)]
+	if !inDict {
+		panic(LoxException{token: bracket, message: fmt.Sprintf("Key '%v' not found", key)})
+	}
+	return value
+}
+
+func (dict *LoxDict) set(bracket Token, key LoxValue, value LoxValue) {
+	/*Stores the value under the given key.
+	New keys are remembered in insertion
+	order.
+	*/
+	dictKey := dict.checkKey(bracket, key)
+	if _, inDict := dict.entries[dictKey]; !inDict {
+		dict.keys = append(dict.keys, dictKey)
+	}
+	dict.entries[dictKey] = value
+}
+
+func (dict *LoxDict) has(key LoxValue) bool {
+	/*Determines if the given key is
+	in the dictionary.
+	*/
+	dictKey, isValid := dict.normalizeKey(key)
+	if !isValid {
+		return false
+	}
+	_, inDict := dict.entries[dictKey]
+	return inDict
+}
+
+func (dict *LoxDict) delete(key LoxValue) LoxValue {
+	/*Removes the given key from the
+	dictionary and returns its value. Returns
+	nil if the key is not in the dictionary.
+	*/
+	dictKey, isValid := dict.normalizeKey(key)
+	if !isValid {
+		return nil
+	}
+	value, inDict := dict.entries[dictKey]
+	if !inDict {
+		return nil
+	}
+
+	delete(dict.entries, dictKey)
+	for i := 0; i < len(dict.keys); i++ {
+		if dict.keys[i] == dictKey {
+			dict.keys = append(dict.keys[:i], dict.keys[i+1:]...)
+			break
+		}
+	}
+	return value
+}
+
+func (dict *LoxDict) checkKey(bracket Token, key LoxValue) LoxValue {
+	/*Returns the normalized version of the
+	given key. Throws an exception if the key
+	is not a string, number or boolean.
+	*/
+	dictKey, isValid := dict.normalizeKey(key)
+	if !isValid {
+		panic(LoxException{token: bracket, message: "Dictionary keys must be strings, numbers or booleans"})
+	}
+	return dictKey
+}
+
+func (dict *LoxDict) normalizeKey(key LoxValue) (LoxValue, bool) {
+	/*Converts a key so that keys equal
+	according to the rules of the lox
+	language map to the same entry. Floats
+	with no fractional part become ints.
+	Returns false if the key is not valid.
+	*/
+	if _, isInt := key.(int64); isInt {
+		return key, true
+	} else if floatKey, isFloat := key.(float64); isFloat {
+		if floatKey == float64(int64(floatKey)) {
+			return int64(floatKey), true
+		}
+		return key, true
+	} else if _, isString := key.(string); isString {
+		return key, true
+	} else if _, isBool := key.(bool); isBool {
+		return key, true
+	} else {
+		return nil, false
+	}
+}
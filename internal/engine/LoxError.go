@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+type LoxException struct {
+	message string
+	token   Token
+	stack   []CallFrame
+}
+
+type FunctionException struct {
+	message string
+}
+
+type LoxThrow struct {
+	value LoxValue
+	token Token
+	stack []CallFrame
+}
+
+type ErrorValue struct {
+	message string
+	line    int
+	token   Token
+	stack   []CallFrame
+}
+
+func (errValue *ErrorValue) get(name Token) LoxValue {
+	/*Returns the value of the property
+	with the given name.
+	*/
+	if name.lexeme == "message" {
+		return errValue.message
+	} else if name.lexeme == "line" {
+		return int64(errValue.line)
+	} else if name.lexeme == "stack" {
+		frames := make([]LoxValue, len(errValue.stack))
+		for i := 0; i < len(errValue.stack); i++ {
+			frames[i] = errValue.stack[i].toDict()
+		}
+		return &LoxList{elements: frames}
+	} else if name.lexeme == "traceback" {
+		return formatTraceback(errValue.stack)
+	}
+
+	panic(LoxException{token: name, message: fmt.Sprintf("Undefined property '%s'", name.lexeme)})
+}
+
+func (errValue *ErrorValue) String() string {
+	/*Returns a human readable string
+	representing the object ErrorValue.
+	*/
+	return errValue.message
+}
+
+type CompileError struct {
+	Diagnostics []Diagnostic
+}
+
+type RuntimeError struct {
+	Diagnostic
+	CallStack []CallFrame `json:"callStack"`
+	Cause     error       `json:"-"`
+}
+
+const TRACEBACK_REPEAT_LIMIT = 3
+
+func newRuntimeError(diagnostic Diagnostic, stack []CallFrame, cause error) *RuntimeError {
+	/*Creates a runtime error. Errors raised
+	outside of functions get an empty call
+	stack instead of nil, so the JSON form
+	always has a list.
+	*/
+	if stack == nil {
+		stack = []CallFrame{}
+	}
+	return &RuntimeError{Diagnostic: diagnostic, CallStack: stack, Cause: cause}
+}
+
+type CallFrame struct {
+	Function       string `json:"function"`
+	File           string `json:"file"`
+	Line           int    `json:"line"`
+	DefinitionLine int    `json:"definitionLine"`
+}
+
+func (err *CompileError) Error() string {
+	/*Returns every error found while
+	compiling, one after the other.
+	*/
+	rendered := make([]string, len(err.Diagnostics))
+	for i := 0; i < len(err.Diagnostics); i++ {
+		rendered[i] = err.Diagnostics[i].String()
+	}
+	return strings.Join(rendered, "\n")
+}
+
+func (err *RuntimeError) Error() string {
+	/*Returns the error message along
+	with the code where it happened and
+	the calls that led to it.
+	*/
+	if len(err.CallStack) == 0 {
+		return err.Diagnostic.String()
+	}
+	return err.Diagnostic.String() + "\n" + err.Traceback()
+}
+
+func (err *RuntimeError) Unwrap() error {
+	/*Returns the reason the program was
+	stopped, such as a context error, if it
+	did not fail on its own.
+	*/
+	return err.Cause
+}
+
+func (err *RuntimeError) Traceback() string {
+	/*Returns the calls that were running
+	when the error happened, most recent
+	call last.
+	*/
+	return formatTraceback(err.CallStack)
+}
+
+func (frame CallFrame) String() string {
+	/*Returns a human readable string
+	representing a call in the traceback.
+	*/
+	definition := "native"
+	if frame.DefinitionLine > 0 {
+		definition = fmt.Sprintf("defined at line %d", frame.DefinitionLine)
+	}
+	return fmt.Sprintf("%s:%d: in %s (%s)", frame.File, frame.Line, frame.Function, definition)
+}
+
+func (frame CallFrame) toDict() *LoxDict {
+	/*Returns the frame as a lox dictionary
+	so catch handlers can inspect it.
+	*/
+	dict := &LoxDict{}
+	dict.init()
+	dict.keys = []LoxValue{"function", "file", "line", "definitionLine"}
+	dict.entries["function"] = frame.Function
+	dict.entries["file"] = frame.File
+	dict.entries["line"] = int64(frame.Line)
+	dict.entries["definitionLine"] = int64(frame.DefinitionLine)
+	return dict
+}
+
+func formatTraceback(stack []CallFrame) string {
+	/*Returns the given call stack as a
+	traceback, most recent call last. Runs
+	of the same frame, as in deep recursion,
+	are collapsed into a single line.
+	*/
+	lines := []string{"Traceback (most recent call last):"}
+	repeated := 0
+	for i := 0; i < len(stack); i++ {
+		if i > 0 && stack[i] == stack[i-1] {
+			repeated++
+		} else {
+			repeated = 0
+		}
+		if repeated < TRACEBACK_REPEAT_LIMIT {
+			lines = append(lines, "  "+stack[i].String())
+		}
+		isRunEnd := i == len(stack)-1 || stack[i+1] != stack[i]
+		if isRunEnd && repeated >= TRACEBACK_REPEAT_LIMIT {
+			lines = append(lines, fmt.Sprintf("  [Previous line repeated %d more times]", repeated-TRACEBACK_REPEAT_LIMIT+1))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
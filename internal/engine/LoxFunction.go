@@ -1,10 +1,12 @@
-package main
+package engine
 
 import "fmt"
 
 type LoxFunction struct {
-	declaration Function
-	closure     *Environment
+	declaration   Function
+	closure       *Environment
+	globals       *Environment
+	isInitializer bool
 }
 
 func (loxFunc LoxFunction) call(interpreter Interpreter, arguments []LoxValue) (returnVal LoxValue) {
@@ -16,6 +18,7 @@ func (loxFunc LoxFunction) call(interpreter Interpreter, arguments []LoxValue) (
 	var env Environment
 	env.init()
 	env.enclosing = loxFunc.closure
+	interpreter.globals = loxFunc.globals
 	returnVal = nil
 
 	for i := 0; i < len(loxFunc.declaration.params); i++ {
@@ -30,11 +33,27 @@ func (loxFunc LoxFunction) call(interpreter Interpreter, arguments []LoxValue) (
 				panic(r)
 			}
 		}
+		if loxFunc.isInitializer {
+			returnVal = loxFunc.closure.values["this"]
+		}
 	}()
 	interpreter.executeBlock(loxFunc.declaration.body, &env)
 	return returnVal
 }
 
+func (loxFunc LoxFunction) bind(instance *LoxInstance) LoxFunction {
+	/*Returns a copy of the function whose
+	closure has 'this' bound to the given
+	instance.
+	*/
+	var env Environment
+	env.init()
+	env.enclosing = loxFunc.closure
+	env.define("this", instance)
+
+	return LoxFunction{declaration: loxFunc.declaration, closure: &env, globals: loxFunc.globals, isInitializer: loxFunc.isInitializer}
+}
+
 func (loxFunc LoxFunction) arity() int {
 	/*Determines the arity of the function
 	 */
@@ -45,5 +64,17 @@ func (loxFunc LoxFunction) String() string {
 	/*Returns a human readable string
 	representing the object LoxFunction.
 	*/
+	if loxFunc.isAnonymous() {
+		return fmt.Sprintf("<fn anonymous@line %d>", loxFunc.declaration.name.line)
+	}
 	return fmt.Sprintf("<fn %s>", loxFunc.declaration.name.lexeme)
 }
+
+func (loxFunc LoxFunction) isAnonymous() bool {
+	/*Determines if the function was created
+	by an anonymous function expression.
+	Anonymous functions are named after the
+	token that introduced them.
+	*/
+	return loxFunc.declaration.name.tokenType != IDENTIFIER
+}
@@ -0,0 +1,37 @@
+package engine
+
+import "fmt"
+
+type LoxInstance struct {
+	class  *LoxClass
+	fields map[string]LoxValue
+}
+
+func (instance *LoxInstance) get(name Token) LoxValue {
+	/*Returns the value of the property
+	with the given name. Fields shadow
+	methods of the same name.
+	*/
+	if value, isField := instance.fields[name.lexeme]; isField {
+		return value
+	}
+	if method, isMethod := instance.class.findMethod(name.lexeme); isMethod {
+		return method.bind(instance)
+	}
+
+	panic(LoxException{token: name, message: fmt.Sprintf("Undefined property '%s'", name.lexeme)})
+}
+
+func (instance *LoxInstance) set(name Token, value LoxValue) {
+	/*Sets the field with the given
+	name to the given value.
+	*/
+	instance.fields[name.lexeme] = value
+}
+
+func (instance *LoxInstance) String() string {
+	/*Returns a human readable string
+	representing the object LoxInstance.
+	*/
+	return fmt.Sprintf("<%s instance>", instance.class.name)
+}
@@ -0,0 +1,41 @@
+package engine
+
+import "fmt"
+
+type LoxList struct {
+	elements []LoxValue
+}
+
+func (list *LoxList) get(bracket Token, index LoxValue) LoxValue {
+	/*Returns the element found at
+	the given index.
+	*/
+	return list.elements[list.checkIndex(bracket, index)]
+}
+
+func (list *LoxList) set(bracket Token, index LoxValue, value LoxValue) {
+	/*Replaces the element found at the
+	given index with the given value.
+	*/
+	list.elements[list.checkIndex(bracket, index)] = value
+}
+
+func (list *LoxList) checkIndex(bracket Token, index LoxValue) int {
+	/*Converts the given index to a position
+	in the list. Negative indices count from
+	the end of the list. Throws an exception
+	if the index is not an integer or is
+	out of range.
+	*/
+	position, isInt := index.(int64)
+	if !isInt {
+		panic(LoxException{token: bracket, message: "List index must be an integer"})
+	}
+	if position < 0 {
+		position += int64(len(list.elements))
+	}
+	if position < 0 || position >= int64(len(list.elements)) {
+		panic(LoxException{token: bracket, message: fmt.Sprintf("List index %d out of range for list of length %d", index.(int64), len(list.elements))})
+	}
+	return int(position)
+}
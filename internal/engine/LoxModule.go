@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const LOX_PATH_VARIABLE = "LOX_PATH"
+
+type LoxModule struct {
+	name    string
+	path    string
+	env     *Environment
+	exports []ModuleExport
+}
+
+type ModuleExport struct {
+	name    string
+	isConst bool
+}
+
+func (module *LoxModule) get(name Token) LoxValue {
+	/*Returns the value of the exported
+	binding with the given name.
+	*/
+	for i := 0; i < len(module.exports); i++ {
+		if module.exports[i].name == name.lexeme {
+			return module.env.values[name.lexeme]
+		}
+	}
+
+	panic(LoxException{token: name, message: fmt.Sprintf("Module '%s' has no binding '%s'", module.name, name.lexeme)})
+}
+
+func (module *LoxModule) String() string {
+	/*Returns a human readable string
+	representing the object LoxModule.
+	*/
+	return fmt.Sprintf("<module %s>", module.name)
+}
+
+func getExports(statements []Stmt) []ModuleExport {
+	/*Returns the bindings declared at the
+	top level of a module and whether they
+	are constant.
+	*/
+	var exports []ModuleExport
+	for i := 0; i < len(statements); i++ {
+		if varStmt, isVar := statements[i].(Var); isVar {
+			exports = append(exports, ModuleExport{name: varStmt.name.lexeme, isConst: varStmt.isConst})
+		} else if funcStmt, isFunc := statements[i].(Function); isFunc {
+			exports = append(exports, ModuleExport{name: funcStmt.name.lexeme})
+		} else if classStmt, isClass := statements[i].(Class); isClass {
+			exports = append(exports, ModuleExport{name: classStmt.name.lexeme})
+		}
+	}
+	return exports
+}
+
+func readExports(importer string, path string) (string, []ModuleExport) {
+	/*Returns the absolute path and the
+	exports of a module without
+	running it, so the resolver of the importing
+	program knows which imported bindings are
+	constant. Modules that can't be found or
+	parsed have no exports here, their import
+	fails when the program runs.
+	*/
+	modulePath, err := findModule(importer, path)
+	if err != nil {
+		return "", nil
+	}
+	srcCode, err := ioutil.ReadFile(modulePath)
+	if err != nil {
+		return modulePath, nil
+	}
+	tokenArr, scnrErrors := runLexer(&Source{name: getDisplayPath(modulePath), text: string(srcCode)})
+	if len(scnrErrors) > 0 {
+		return modulePath, nil
+	}
+	stmtArr, parserErrors := runParser(tokenArr, false)
+	if len(parserErrors) > 0 {
+		return modulePath, nil
+	}
+	return modulePath, getExports(stmtArr)
+}
+
+func findModule(importer string, path string) (string, error) {
+	/*Returns the absolute path of the module
+	imported with the given path. Relative paths
+	are looked up next to the importing file
+	first and then in every directory listed in
+	the LOX_PATH environment variable.
+	*/
+	var candidates []string
+	if filepath.IsAbs(path) {
+		candidates = append(candidates, path)
+	} else {
+		baseDir := "."
+		if importer != "" {
+			baseDir = filepath.Dir(importer)
+		}
+		candidates = append(candidates, filepath.Join(baseDir, path))
+
+		searchPath := filepath.SplitList(os.Getenv(LOX_PATH_VARIABLE))
+		for i := 0; i < len(searchPath); i++ {
+			if searchPath[i] != "" {
+				candidates = append(candidates, filepath.Join(searchPath[i], path))
+			}
+		}
+	}
+
+	for i := 0; i < len(candidates); i++ {
+		if info, err := os.Stat(candidates[i]); err == nil && !info.IsDir() {
+			return filepath.Abs(candidates[i])
+		}
+	}
+	return "", errors.New(fmt.Sprintf("Cannot find module '%s'", path))
+}
+
+func getDisplayPath(path string) string {
+	/*Returns the path of a module relative
+	to the working directory when possible
+	so diagnostics stay short.
+	*/
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	relativePath, err := filepath.Rel(workingDir, path)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return path
+	}
+	return relativePath
+}
+
+func getModuleName(path string) string {
+	/*Returns the name of a module, which
+	is its file name without extension.
+	*/
+	fileName := filepath.Base(path)
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName))
+}
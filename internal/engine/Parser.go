@@ -1,13 +1,15 @@
-package main
+package engine
 
 import (
 	"strconv"
 )
 
 type Parser struct {
-	tokens   []Token
-	index    int
-	loxError bool
+	tokens     []Token
+	index      int
+	errors     []Diagnostic
+	incomplete bool
+	replMode   bool
 }
 
 func (parser *Parser) init(tokenList []Token) {
@@ -16,7 +18,9 @@ func (parser *Parser) init(tokenList []Token) {
 	*/
 	parser.tokens = tokenList
 	parser.index = 0
-	parser.loxError = false
+	parser.errors = []Diagnostic{}
+	parser.incomplete = false
+	parser.replMode = false
 }
 
 func (parser *Parser) parseTokens() []Stmt {
@@ -51,12 +55,39 @@ func (parser *Parser) declaration() Stmt {
 	} else if parser.matchAndAdvance(CONST) {
 		return parser.constDeclaration()
 	} else if parser.matchAndAdvance(FUN) {
-		return parser.function()
+		return parser.function("function")
+	} else if parser.matchAndAdvance(CLASS) {
+		return parser.classDeclaration()
+	} else if parser.matchAndAdvance(IMPORT) {
+		return parser.importDeclaration()
 	} else {
 		return parser.statement()
 	}
 }
 
+func (parser *Parser) importDeclaration() Stmt {
+	/*Representation of an import declaration
+	as a grammar rule. The word 'from' is only
+	special right after the module name.
+	*/
+	keyword := parser.previousToken()
+	hasName := false
+	var name Token
+
+	if parser.matchAndAdvance(IDENTIFIER) {
+		hasName = true
+		name = parser.previousToken()
+		from := parser.consume(IDENTIFIER, "Expect 'from' after module name")
+		if from.lexeme != "from" {
+			panic(parser.compilerError(from, "Expect 'from' after module name"))
+		}
+	}
+	path := parser.consume(STRING, "Expect module path")
+	parser.consume(SEMICOLON, "Expect ';' after import")
+
+	return Import{keyword: keyword, path: path, hasName: hasName, name: name}
+}
+
 func (parser *Parser) constDeclaration() Stmt {
 	/*Used to set a flag for
 	variable declarations.
@@ -74,18 +105,59 @@ func (parser *Parser) varDeclaration(isConstant bool) Stmt {
 
 	if parser.matchAndAdvance(EQUAL) {
 		initializer = parser.expression()
+	} else if isConstant {
+		parser.compilerError(name, "Constant variable must be initialized")
 	}
 
 	parser.consume(SEMICOLON, "Expect ';' after variable declaration")
 	return Var{name: name, initializer: initializer, isConst: isConstant}
 }
 
-func (parser *Parser) function() Stmt {
-	/*Representation of a function declaration
+func (parser *Parser) classDeclaration() Stmt {
+	/*Representation of a class declaration
 	as a grammar rule.
 	*/
-	name := parser.consume(IDENTIFIER, "Expect function name")
-	parser.consume(LEFT_PAREN, "Expect '(' after function name")
+	name := parser.consume(IDENTIFIER, "Expect class name")
+
+	var superclass Expr = nil
+	if parser.matchAndAdvance(LESS_THAN) {
+		superName := parser.consume(IDENTIFIER, "Expect superclass name")
+		if superName.lexeme == name.lexeme {
+			parser.compilerError(superName, "A class can't inherit from itself")
+		}
+		superclass = Variable{name: superName, depth: parser.unresolvedDepth()}
+	}
+
+	parser.consume(LEFT_BRACE, "Expect '{' before class body")
+
+	var methods []Function
+	for !parser.atEnd() && !parser.matchTokenType(RIGHT_BRACE) {
+		methods = append(methods, parser.function("method").(Function))
+	}
+
+	parser.consume(RIGHT_BRACE, "Expect '}' after class body")
+	return Class{name: name, superclass: superclass, methods: methods}
+}
+
+func (parser *Parser) function(kind string) Stmt {
+	/*Representation of a function declaration
+	as a grammar rule. The kind is used to
+	tell functions and methods apart in
+	error messages.
+	*/
+	name := parser.consume(IDENTIFIER, "Expect "+kind+" name")
+	parser.consume(LEFT_PAREN, "Expect '(' after "+kind+" name")
+	parameters := parser.parameters()
+
+	parser.consume(LEFT_BRACE, "Expect '{' before "+kind+" body")
+	body := parser.block()
+	return Function{name: name, params: parameters, body: body}
+}
+
+func (parser *Parser) parameters() []Token {
+	/*Parses the parameter list of a function
+	up to and including the closing parenthesis.
+	*/
 	var parameters []Token
 
 	if !parser.matchTokenType(RIGHT_PAREN) {
@@ -101,10 +173,7 @@ func (parser *Parser) function() Stmt {
 		}
 	}
 	parser.consume(RIGHT_PAREN, "Expect ')' after parameters")
-
-	parser.consume(LEFT_BRACE, "Expect '{' before function body")
-	body := parser.block()
-	return Function{name: name, params: parameters, body: body}
+	return parameters
 }
 
 func (parser *Parser) statement() Stmt {
@@ -123,6 +192,18 @@ func (parser *Parser) statement() Stmt {
 		return parser.forStatement()
 	} else if parser.matchAndAdvance(RETURN) {
 		return parser.returnStatement()
+	} else if parser.matchAndAdvance(TRY) {
+		return parser.tryStatement()
+	} else if parser.matchAndAdvance(THROW) {
+		return parser.throwStatement()
+	} else if parser.matchAndAdvance(BREAK) {
+		keyword := parser.previousToken()
+		parser.consume(SEMICOLON, "Expect ';' after 'break'")
+		return Break{keyword: keyword}
+	} else if parser.matchAndAdvance(CONTINUE) {
+		keyword := parser.previousToken()
+		parser.consume(SEMICOLON, "Expect ';' after 'continue'")
+		return Continue{keyword: keyword}
 	} else {
 		return parser.expressionStatement()
 	}
@@ -174,18 +255,20 @@ func (parser *Parser) whileStatement() Stmt {
 	/*Representation of a while statement
 	as a grammar rule.
 	*/
+	keyword := parser.previousToken()
 	parser.consume(LEFT_PAREN, "Expect '(' after 'while'")
 	condition := parser.expression()
 	parser.consume(RIGHT_PAREN, "Expect ')' after condition")
 	body := parser.statement()
 
-	return While{condition: condition, body: body}
+	return While{keyword: keyword, condition: condition, body: body}
 }
 
 func (parser *Parser) forStatement() Stmt {
 	/*Representation of a for loop statement
 	as a grammar rule.
 	*/
+	keyword := parser.previousToken()
 	parser.consume(LEFT_PAREN, "Expect '(' after 'for'")
 
 	var initializer Stmt = nil
@@ -213,13 +296,10 @@ func (parser *Parser) forStatement() Stmt {
 
 	body := parser.statement()
 
-	if increment != nil {
-		body = Block{statements: []Stmt{body, Expression{expression: increment}}}
-	}
 	if condition == nil {
 		condition = Literal{value: true}
 	}
-	body = While{condition: condition, body: body}
+	body = While{keyword: keyword, condition: condition, body: body, increment: increment}
 
 	if initializer != nil {
 		body = Block{statements: []Stmt{initializer, body}}
@@ -228,11 +308,59 @@ func (parser *Parser) forStatement() Stmt {
 	return body
 }
 
+func (parser *Parser) tryStatement() Stmt {
+	/*Representation of a try statement as
+	a grammar rule. It needs a catch clause,
+	a finally clause or both.
+	*/
+	keyword := parser.previousToken()
+	parser.consume(LEFT_BRACE, "Expect '{' after 'try'")
+	body := parser.block()
+
+	hasCatch := false
+	var catchName Token
+	var catchBody []Stmt
+	if parser.matchAndAdvance(CATCH) {
+		hasCatch = true
+		parser.consume(LEFT_PAREN, "Expect '(' after 'catch'")
+		catchName = parser.consume(IDENTIFIER, "Expect error variable name")
+		parser.consume(RIGHT_PAREN, "Expect ')' after error variable name")
+		parser.consume(LEFT_BRACE, "Expect '{' after catch clause")
+		catchBody = parser.block()
+	}
+
+	var finallyBody []Stmt
+	if parser.matchAndAdvance(FINALLY) {
+		parser.consume(LEFT_BRACE, "Expect '{' after 'finally'")
+		finallyBody = parser.block()
+	} else if !hasCatch {
+		panic(parser.compilerError(parser.getCurrentToken(), "Expect 'catch' or 'finally' after try block"))
+	}
+
+	return Try{keyword: keyword, body: body, hasCatch: hasCatch, catchName: catchName, catchBody: catchBody, finallyBody: finallyBody}
+}
+
+func (parser *Parser) throwStatement() Stmt {
+	/*Representation of a throw statement as
+	a grammar rule.
+	*/
+	keyword := parser.previousToken()
+	value := parser.expression()
+	parser.consume(SEMICOLON, "Expect ';' after thrown value")
+
+	return Throw{keyword: keyword, value: value}
+}
+
 func (parser *Parser) expressionStatement() Stmt {
 	/*Representation of expression statement
-	as a grammar rule.
+	as a grammar rule. In the REPL the last
+	statement of the input may omit the
+	semicolon.
 	*/
 	expr := parser.expression()
+	if parser.replMode && parser.atEnd() {
+		return Expression{expression: expr}
+	}
 	parser.consume(SEMICOLON, "Expect ';' after expression")
 
 	return Expression{expression: expr}
@@ -272,7 +400,11 @@ func (parser *Parser) assignment() Expr {
 
 		if _, isVar := expr.(Variable); isVar {
 			name := expr.(Variable).name
-			return Assign{name: name, value: value}
+			return Assign{name: name, value: value, depth: parser.unresolvedDepth()}
+		} else if get, isGet := expr.(Get); isGet {
+			return Set{object: get.object, name: get.name, value: value}
+		} else if index, isIndex := expr.(Index); isIndex {
+			return IndexSet{object: index.object, bracket: index.bracket, index: index.index, value: value}
 		} else {
 			panic(parser.compilerError(equals, "Invalid assignment target"))
 		}
@@ -394,6 +526,13 @@ func (parser *Parser) call() Expr {
 	for {
 		if parser.matchAndAdvance(LEFT_PAREN) {
 			expr = parser.finishCall(expr)
+		} else if parser.matchAndAdvance(DOT) {
+			name := parser.consume(IDENTIFIER, "Expect property name after '.'")
+			expr = Get{object: expr, name: name}
+		} else if parser.matchAndAdvance(LEFT_BRACKET) {
+			index := parser.expression()
+			bracket := parser.consume(RIGHT_BRACKET, "Expect ']' after index")
+			expr = Index{object: expr, bracket: bracket, index: index}
 		} else {
 			break
 		}
@@ -433,7 +572,9 @@ func (parser *Parser) primary() Expr {
 	} else if parser.matchAndAdvance(NIL) {
 		return Literal{value: nil}
 	} else if parser.matchAndAdvance(STRING) {
-		return Literal{value: parser.previousToken().lexeme}
+		return Literal{value: parser.previousToken().literal}
+	} else if parser.matchAndAdvance(INTERPOLATION) {
+		return parser.interpolation()
 	} else if parser.matchAndAdvance(NUMBER) {
 		literalInt, err := strconv.ParseInt(parser.previousToken().lexeme, 10, 64)
 		if err == nil {
@@ -442,17 +583,167 @@ func (parser *Parser) primary() Expr {
 			literalFloat, _ := strconv.ParseFloat(parser.previousToken().lexeme, 64)
 			return Literal{value: literalFloat}
 		}
+	} else if parser.matchAndAdvance(FUN) {
+		return parser.lambda()
+	} else if parser.isArrowFunction() {
+		return parser.arrowFunction()
 	} else if parser.matchAndAdvance(LEFT_PAREN) {
 		expr := parser.expression()
 		parser.consume(RIGHT_PAREN, "Expect ')' after expression")
 		return Grouping{expression: expr}
+	} else if parser.matchAndAdvance(LEFT_BRACKET) {
+		return parser.list()
+	} else if parser.matchAndAdvance(LEFT_BRACE) {
+		return parser.dict()
+	} else if parser.matchAndAdvance(SUPER) {
+		keyword := parser.previousToken()
+		parser.consume(DOT, "Expect '.' after 'super'")
+		method := parser.consume(IDENTIFIER, "Expect superclass method name")
+		return Super{keyword: keyword, method: method, depth: parser.unresolvedDepth()}
+	} else if parser.matchAndAdvance(THIS) {
+		return This{keyword: parser.previousToken(), depth: parser.unresolvedDepth()}
 	} else if parser.matchAndAdvance(IDENTIFIER) {
-		return Variable{name: parser.previousToken()}
+		return Variable{name: parser.previousToken(), depth: parser.unresolvedDepth()}
 	} else {
 		panic(parser.compilerError(parser.getCurrentToken(), "Expect expression"))
 	}
 }
 
+func (parser *Parser) unresolvedDepth() *int {
+	/*Returns a new scope depth for a
+	variable reference. It is filled in
+	by the resolver and starts out
+	pointing at the global scope.
+	*/
+	depth := GLOBAL_DEPTH
+	return &depth
+}
+
+func (parser *Parser) lambda() Expr {
+	/*Representation of an anonymous function
+	as a grammar rule. A statement starting
+	with 'fun' is always a declaration, so an
+	anonymous function used as a statement
+	needs parentheses around it.
+	*/
+	keyword := parser.previousToken()
+	parser.consume(LEFT_PAREN, "Expect '(' after 'fun'")
+	parameters := parser.parameters()
+
+	parser.consume(LEFT_BRACE, "Expect '{' before function body")
+	body := parser.block()
+	return Lambda{declaration: Function{name: keyword, params: parameters, body: body}}
+}
+
+func (parser *Parser) arrowFunction() Expr {
+	/*Representation of the short form of an
+	anonymous function as a grammar rule. The
+	body is a single expression whose value
+	is returned.
+	*/
+	parser.consume(LEFT_PAREN, "Expect '(' before parameters")
+	parameters := parser.parameters()
+	arrow := parser.consume(ARROW, "Expect '=>' after parameters")
+	value := parser.expression()
+
+	body := []Stmt{Return{keyword: arrow, value: value}}
+	return Lambda{declaration: Function{name: arrow, params: parameters, body: body}}
+}
+
+func (parser *Parser) isArrowFunction() bool {
+	/*Looks ahead without consuming tokens to
+	determine whether a '(' starts the parameter
+	list of an arrow function rather than a
+	grouping.
+	*/
+	if !parser.matchTokenType(LEFT_PAREN) {
+		return false
+	}
+
+	i := parser.index + 1
+	if parser.tokens[i].tokenType != RIGHT_PAREN {
+		for {
+			if parser.tokens[i].tokenType != IDENTIFIER {
+				return false
+			}
+			i++
+			if parser.tokens[i].tokenType != COMMA {
+				break
+			}
+			i++
+		}
+	}
+	return parser.tokens[i].tokenType == RIGHT_PAREN && parser.tokens[i+1].tokenType == ARROW
+}
+
+func (parser *Parser) interpolation() Expr {
+	/*Representation of an interpolated string
+	as a grammar rule. The string is split into
+	its literal text and embedded expressions.
+	*/
+	quote := parser.previousToken()
+	var parts []Expr
+
+	for {
+		if text := parser.previousToken().literal.(string); text != "" {
+			parts = append(parts, Literal{value: text})
+		}
+		parts = append(parts, parser.expression())
+
+		if !parser.matchAndAdvance(INTERPOLATION) {
+			break
+		}
+	}
+	end := parser.consume(STRING, "Expect '}' after interpolated expression")
+	if text := end.literal.(string); text != "" {
+		parts = append(parts, Literal{value: text})
+	}
+
+	return Interpolation{quote: quote, parts: parts}
+}
+
+func (parser *Parser) list() Expr {
+	/*Representation of a list literal
+	as a grammar rule.
+	*/
+	var elements []Expr
+
+	if !parser.matchTokenType(RIGHT_BRACKET) {
+		for {
+			elements = append(elements, parser.expression())
+			if !parser.matchAndAdvance(COMMA) {
+				break
+			}
+		}
+	}
+	bracket := parser.consume(RIGHT_BRACKET, "Expect ']' after list elements")
+	return List{bracket: bracket, elements: elements}
+}
+
+func (parser *Parser) dict() Expr {
+	/*Representation of a dictionary literal
+	as a grammar rule. A '{' at the start of
+	a statement always begins a block, so
+	dictionary literals are only parsed
+	where an expression is expected.
+	*/
+	var keys []Expr
+	var values []Expr
+
+	if !parser.matchTokenType(RIGHT_BRACE) {
+		for {
+			keys = append(keys, parser.expression())
+			parser.consume(COLON, "Expect ':' after dictionary key")
+			values = append(values, parser.expression())
+			if !parser.matchAndAdvance(COMMA) {
+				break
+			}
+		}
+	}
+	brace := parser.consume(RIGHT_BRACE, "Expect '}' after dictionary entries")
+	return Dict{brace: brace, keys: keys, values: values}
+}
+
 func (parser *Parser) getCurrentToken() Token {
 	/*Returns current token
 	according to the index field
@@ -509,12 +800,22 @@ func (parser *Parser) consume(tokenType TokenType, errMessage string) Token {
 }
 
 func (parser *Parser) compilerError(token Token, message string) LoxException {
-	/*Sets parser field loxError to true and
-	prints the error message. Returns a lox
-	error type.
+	/*Adds the error message to the errors
+	of the parser. The parse is incomplete if
+	the first error is found at the end of the
+	tokens. Returns a lox error type.
 	*/
-	parser.loxError = true
-	return loxError(token, message, parser.atEnd())
+	if len(parser.errors) == 0 {
+		parser.incomplete = parser.atEnd()
+	}
+	if parser.atEnd() && parser.index > 0 {
+		//Errors at the end point right after the last token.
+		previous := parser.previousToken()
+		parser.errors = append(parser.errors, newDiagnostic(COMPILE_ERROR, previous.source, previous.offset+len(previous.lexeme), 1, message))
+	} else {
+		parser.errors = append(parser.errors, tokenDiagnostic(COMPILE_ERROR, token, message))
+	}
+	return LoxException{message: message, token: token}
 }
 
 func (parser *Parser) previousToken() Token {
@@ -546,6 +847,8 @@ func (parser *Parser) synchronize() bool {
 			tokenType := parser.getCurrentTokenType()
 
 			switch tokenType {
+			case CLASS:
+				return true
 			case FUN:
 				return true
 			case VAR:
@@ -560,6 +863,12 @@ func (parser *Parser) synchronize() bool {
 				return true
 			case RETURN:
 				return true
+			case IMPORT:
+				return true
+			case TRY:
+				return true
+			case THROW:
+				return true
 			}
 
 			parser.index++
@@ -0,0 +1,599 @@
+package engine
+
+import "fmt"
+
+type FunctionType int
+
+const (
+	NO_FUNCTION = iota
+	IN_FUNCTION
+	IN_METHOD
+	IN_INITIALIZER
+)
+
+type ClassType int
+
+const (
+	NO_CLASS = iota
+	IN_CLASS
+	IN_SUBCLASS
+)
+
+const GLOBAL_DEPTH = -1
+
+type ScopeVariable struct {
+	defined bool
+	isConst bool
+}
+
+type Resolver struct {
+	scopes          []map[string]ScopeVariable
+	globalConsts    map[string]string
+	pendingConsts   map[string]string
+	scriptPath      string
+	readModules     bool
+	redefineGlobals bool
+	currentFunction FunctionType
+	currentClass    ClassType
+	loopDepth       int
+	errors          []Diagnostic
+}
+
+func (resolver *Resolver) init() {
+	/*Initializes a resolver
+	object.
+	*/
+	resolver.scopes = []map[string]ScopeVariable{}
+	resolver.globalConsts = map[string]string{}
+	resolver.pendingConsts = map[string]string{}
+	resolver.currentFunction = NO_FUNCTION
+	resolver.currentClass = NO_CLASS
+	resolver.loopDepth = 0
+	resolver.errors = []Diagnostic{}
+}
+
+func (resolver *Resolver) resolveProgram(statements []Stmt) {
+	/*Resolves the top level statements of
+	a program. Global constants are collected
+	first so assignments to them are caught
+	even inside functions declared before them.
+	Global constants can't be declared again,
+	not even by a later program in the REPL.
+	The constants of the program stay pending
+	until commitConsts is called after it ran.
+	Unless redefineGlobals is set, a program
+	can't declare the same global twice.
+	*/
+	resolver.errors = []Diagnostic{}
+	resolver.pendingConsts = map[string]string{}
+	declared := map[string]bool{}
+	for i := 0; i < len(statements); i++ {
+		if name, isDeclaration := resolver.declaredName(statements[i]); isDeclaration {
+			if _, isConst := resolver.globalConstSource(name.lexeme); isConst {
+				resolver.error(name, fmt.Sprintf("Cannot redeclare constant variable '%s'", name.lexeme))
+			} else if declared[name.lexeme] && !resolver.redefineGlobals {
+				resolver.error(name, "Already a variable with this name in this scope")
+			}
+			declared[name.lexeme] = true
+		}
+		if varStmt, isVar := statements[i].(Var); isVar && varStmt.isConst {
+			resolver.pendingConsts[varStmt.name.lexeme] = ""
+		} else if importStmt, isImport := statements[i].(Import); isImport && !importStmt.hasName {
+			resolver.resolveImportedConsts(importStmt)
+		}
+	}
+	resolver.resolveStatements(statements)
+}
+
+func (resolver *Resolver) resolveImportedConsts(stmt Import) {
+	/*Reads the exports of a module imported
+	without a name. Its constants become constants
+	of the program and it can't replace constants
+	declared before, except the ones it exported
+	itself when it is imported again. Modules are
+	only read when the program may access the
+	filesystem.
+	*/
+	if !resolver.readModules {
+		return
+	}
+	modulePath, exports := readExports(resolver.scriptPath, stmt.path.literal.(string))
+	for i := 0; i < len(exports); i++ {
+		source, isConst := resolver.globalConstSource(exports[i].name)
+		if isConst && !(exports[i].isConst && source == modulePath) {
+			resolver.error(stmt.path, fmt.Sprintf("Cannot redeclare constant variable '%s'", exports[i].name))
+		} else if exports[i].isConst {
+			resolver.pendingConsts[exports[i].name] = modulePath
+		}
+	}
+}
+
+func (resolver *Resolver) globalConstSource(name string) (string, bool) {
+	/*Determines if the global variable with
+	the given name is constant, either from an
+	earlier program or from the current one.
+	Also returns the path of the module that
+	declared it, empty if the program did.
+	*/
+	if source, isConst := resolver.pendingConsts[name]; isConst {
+		return source, true
+	}
+	source, isConst := resolver.globalConsts[name]
+	return source, isConst
+}
+
+func (resolver *Resolver) commitConsts() {
+	/*Makes the constants of the last resolved
+	program permanent. The map is copied so
+	other copies of the resolver are left as
+	they were.
+	*/
+	globalConsts := map[string]string{}
+	for name, source := range resolver.globalConsts {
+		globalConsts[name] = source
+	}
+	for name, source := range resolver.pendingConsts {
+		globalConsts[name] = source
+	}
+	resolver.globalConsts = globalConsts
+	resolver.pendingConsts = map[string]string{}
+}
+
+func (resolver *Resolver) declaredName(stmt Stmt) (Token, bool) {
+	/*Returns the name declared by the given
+	statement if it is a declaration.
+	*/
+	if varStmt, isVar := stmt.(Var); isVar {
+		return varStmt.name, true
+	} else if funcStmt, isFunc := stmt.(Function); isFunc {
+		return funcStmt.name, true
+	} else if classStmt, isClass := stmt.(Class); isClass {
+		return classStmt.name, true
+	} else if importStmt, isImport := stmt.(Import); isImport && importStmt.hasName {
+		return importStmt.name, true
+	}
+	return Token{}, false
+}
+
+func (resolver *Resolver) resolveStatements(statements []Stmt) {
+	/*Resolves every statement in
+	the given slice.
+	*/
+	for i := 0; i < len(statements); i++ {
+		resolver.resolveStmt(statements[i])
+	}
+}
+
+func (resolver *Resolver) resolveStmt(stmt Stmt) {
+	/*Resolves the given statement.
+	 */
+	if stmt != nil {
+		stmt.resolve(resolver)
+	}
+}
+
+func (resolver *Resolver) resolveExpr(expr Expr) {
+	/*Resolves the given expression.
+	 */
+	if expr != nil {
+		expr.resolve(resolver)
+	}
+}
+
+func (resolver *Resolver) resolveFunction(function Function, functionType FunctionType) {
+	/*Resolves the parameters and body
+	of a function inside a new scope.
+	*/
+	enclosingFunction := resolver.currentFunction
+	enclosingLoopDepth := resolver.loopDepth
+	resolver.currentFunction = functionType
+	resolver.loopDepth = 0
+
+	resolver.beginScope()
+	for i := 0; i < len(function.params); i++ {
+		resolver.declare(function.params[i], false)
+		resolver.define(function.params[i])
+	}
+	resolver.resolveStatements(function.body)
+	resolver.endScope()
+
+	resolver.currentFunction = enclosingFunction
+	resolver.loopDepth = enclosingLoopDepth
+}
+
+func (resolver *Resolver) resolveLocal(name Token, depth *int) {
+	/*Stores in depth the number of scopes
+	between the innermost scope and the scope
+	where the variable was declared. Variables
+	not found in any scope are left as globals.
+	*/
+	*depth = GLOBAL_DEPTH
+	for i := len(resolver.scopes) - 1; i >= 0; i-- {
+		if _, inScope := resolver.scopes[i][name.lexeme]; inScope {
+			*depth = len(resolver.scopes) - 1 - i
+			return
+		}
+	}
+}
+
+func (resolver *Resolver) isConst(name Token, depth int) bool {
+	/*Determines if the variable found at
+	the given depth was declared constant.
+	*/
+	if depth == GLOBAL_DEPTH {
+		_, isConst := resolver.globalConstSource(name.lexeme)
+		return isConst
+	}
+	return resolver.scopes[len(resolver.scopes)-1-depth][name.lexeme].isConst
+}
+
+func (resolver *Resolver) beginScope() {
+	/*Pushes a new scope onto
+	the stack of scopes.
+	*/
+	resolver.scopes = append(resolver.scopes, map[string]ScopeVariable{})
+}
+
+func (resolver *Resolver) endScope() {
+	/*Pops the innermost scope from
+	the stack of scopes.
+	*/
+	resolver.scopes = resolver.scopes[:len(resolver.scopes)-1]
+}
+
+func (resolver *Resolver) declare(name Token, isConst bool) {
+	/*Adds a variable to the innermost scope
+	and marks it as not ready to be used.
+	*/
+	if len(resolver.scopes) == 0 {
+		return
+	}
+	scope := resolver.scopes[len(resolver.scopes)-1]
+	if _, inScope := scope[name.lexeme]; inScope {
+		resolver.error(name, "Already a variable with this name in this scope")
+	}
+	scope[name.lexeme] = ScopeVariable{defined: false, isConst: isConst}
+}
+
+func (resolver *Resolver) define(name Token) {
+	/*Marks a declared variable in the
+	innermost scope as ready to be used.
+	*/
+	if len(resolver.scopes) == 0 {
+		return
+	}
+	scope := resolver.scopes[len(resolver.scopes)-1]
+	variable := scope[name.lexeme]
+	variable.defined = true
+	scope[name.lexeme] = variable
+}
+
+func (resolver *Resolver) error(token Token, message string) {
+	/*Adds the error message to the
+	errors of the resolver.
+	*/
+	resolver.errors = append(resolver.errors, tokenDiagnostic(COMPILE_ERROR, token, message))
+}
+
+func (resolver *Resolver) visitBlockStmt(stmt Block) {
+	/*Resolves the statements of a
+	block inside a new scope.
+	*/
+	resolver.beginScope()
+	resolver.resolveStatements(stmt.statements)
+	resolver.endScope()
+}
+
+func (resolver *Resolver) visitExpressionStmt(stmt Expression) {
+	/*Resolves an expression statement.
+	 */
+	resolver.resolveExpr(stmt.expression)
+}
+
+func (resolver *Resolver) visitIfStmt(stmt If) {
+	/*Resolves the condition and both
+	branches of an if statement.
+	*/
+	resolver.resolveExpr(stmt.condition)
+	resolver.resolveStmt(stmt.thenBranch)
+	resolver.resolveStmt(stmt.elseBranch)
+}
+
+func (resolver *Resolver) visitPrintStmt(stmt Print) {
+	/*Resolves a print statement.
+	 */
+	resolver.resolveExpr(stmt.expression)
+}
+
+func (resolver *Resolver) visitWhileStmt(stmt While) {
+	/*Resolves the condition and body
+	of a while statement.
+	*/
+	resolver.resolveExpr(stmt.condition)
+	resolver.loopDepth++
+	resolver.resolveStmt(stmt.body)
+	resolver.loopDepth--
+	resolver.resolveExpr(stmt.increment)
+}
+
+func (resolver *Resolver) visitBreakStmt(stmt Break) {
+	/*Resolves a break statement.
+	 */
+	if resolver.loopDepth == 0 {
+		resolver.error(stmt.keyword, "Can't use 'break' outside of a loop")
+	}
+}
+
+func (resolver *Resolver) visitContinueStmt(stmt Continue) {
+	/*Resolves a continue statement.
+	 */
+	if resolver.loopDepth == 0 {
+		resolver.error(stmt.keyword, "Can't use 'continue' outside of a loop")
+	}
+}
+
+func (resolver *Resolver) visitVarStmt(stmt Var) {
+	/*Resolves a variable declaration. The
+	variable is declared before its initializer
+	is resolved so it can't refer to itself.
+	*/
+	resolver.declare(stmt.name, stmt.isConst)
+	resolver.resolveExpr(stmt.initializer)
+	resolver.define(stmt.name)
+}
+
+func (resolver *Resolver) visitFunctionStmt(stmt Function) {
+	/*Resolves a function declaration. The
+	name is defined before the body so the
+	function can refer to itself.
+	*/
+	resolver.declare(stmt.name, false)
+	resolver.define(stmt.name)
+	resolver.resolveFunction(stmt, IN_FUNCTION)
+}
+
+func (resolver *Resolver) visitReturnStmt(stmt Return) {
+	/*Resolves a return statement.
+	 */
+	if resolver.currentFunction == NO_FUNCTION {
+		resolver.error(stmt.keyword, "Can't return from top-level code")
+	}
+	if stmt.value != nil {
+		if resolver.currentFunction == IN_INITIALIZER {
+			resolver.error(stmt.keyword, "Can't return a value from an initializer")
+		}
+		resolver.resolveExpr(stmt.value)
+	}
+}
+
+func (resolver *Resolver) visitImportStmt(stmt Import) {
+	/*Resolves an import declaration. Modules
+	can only be imported at the top level.
+	*/
+	if len(resolver.scopes) > 0 || resolver.currentFunction != NO_FUNCTION {
+		resolver.error(stmt.keyword, "Can't import outside of top-level code")
+	}
+}
+
+func (resolver *Resolver) visitThrowStmt(stmt Throw) {
+	/*Resolves a throw statement.
+	 */
+	resolver.resolveExpr(stmt.value)
+}
+
+func (resolver *Resolver) visitTryStmt(stmt Try) {
+	/*Resolves the blocks of a try statement.
+	The error variable is declared in the
+	scope of the catch block.
+	*/
+	resolver.beginScope()
+	resolver.resolveStatements(stmt.body)
+	resolver.endScope()
+
+	if stmt.hasCatch {
+		resolver.beginScope()
+		resolver.declare(stmt.catchName, false)
+		resolver.define(stmt.catchName)
+		resolver.resolveStatements(stmt.catchBody)
+		resolver.endScope()
+	}
+
+	resolver.beginScope()
+	resolver.resolveStatements(stmt.finallyBody)
+	resolver.endScope()
+}
+
+func (resolver *Resolver) visitClassStmt(stmt Class) {
+	/*Resolves a class declaration and
+	its methods. Methods are resolved inside
+	the scopes binding 'super' and 'this'.
+	*/
+	enclosingClass := resolver.currentClass
+	resolver.currentClass = IN_CLASS
+
+	resolver.declare(stmt.name, false)
+	resolver.define(stmt.name)
+
+	if stmt.superclass != nil {
+		resolver.currentClass = IN_SUBCLASS
+		resolver.resolveExpr(stmt.superclass)
+		resolver.beginScope()
+		resolver.scopes[len(resolver.scopes)-1]["super"] = ScopeVariable{defined: true}
+	}
+
+	resolver.beginScope()
+	resolver.scopes[len(resolver.scopes)-1]["this"] = ScopeVariable{defined: true}
+	for i := 0; i < len(stmt.methods); i++ {
+		var functionType FunctionType = IN_METHOD
+		if stmt.methods[i].name.lexeme == "init" {
+			functionType = IN_INITIALIZER
+		}
+		resolver.resolveFunction(stmt.methods[i], functionType)
+	}
+	resolver.endScope()
+
+	if stmt.superclass != nil {
+		resolver.endScope()
+	}
+	resolver.currentClass = enclosingClass
+}
+
+func (resolver *Resolver) visitAssignExpr(expr Assign) {
+	/*Resolves the value of an assignment
+	and the variable being assigned. Constant
+	variables can't be assigned to.
+	*/
+	resolver.resolveExpr(expr.value)
+	resolver.resolveLocal(expr.name, expr.depth)
+	if resolver.isConst(expr.name, *expr.depth) {
+		resolver.error(expr.name, fmt.Sprintf("Cannot reassign constant variable '%s'", expr.name.lexeme))
+	}
+}
+
+func (resolver *Resolver) visitBinaryExpr(expr Binary) {
+	/*Resolves both operands of a binary
+	expression.
+	*/
+	resolver.resolveExpr(expr.left)
+	resolver.resolveExpr(expr.right)
+}
+
+func (resolver *Resolver) visitCallExpr(expr Call) {
+	/*Resolves the callee and arguments
+	of a call expression.
+	*/
+	resolver.resolveExpr(expr.callee)
+	for i := 0; i < len(expr.arguments); i++ {
+		resolver.resolveExpr(expr.arguments[i])
+	}
+}
+
+func (resolver *Resolver) visitGroupingExpr(expr Grouping) {
+	/*Resolves the expression inside
+	the parenthesis.
+	*/
+	resolver.resolveExpr(expr.expression)
+}
+
+func (resolver *Resolver) visitLiteralExpr(expr Literal) {
+	/*Literals have nothing to resolve.
+	 */
+}
+
+func (resolver *Resolver) visitLogicalExpr(expr Logical) {
+	/*Resolves both operands of a logical
+	expression.
+	*/
+	resolver.resolveExpr(expr.left)
+	resolver.resolveExpr(expr.right)
+}
+
+func (resolver *Resolver) visitUnaryExpr(expr Unary) {
+	/*Resolves the operand of a unary
+	expression.
+	*/
+	resolver.resolveExpr(expr.right)
+}
+
+func (resolver *Resolver) visitVariableExpr(expr Variable) {
+	/*Resolves a variable expression.
+	 */
+	if len(resolver.scopes) > 0 {
+		if variable, inScope := resolver.scopes[len(resolver.scopes)-1][expr.name.lexeme]; inScope && !variable.defined {
+			resolver.error(expr.name, "Can't read local variable in its own initializer")
+		}
+	}
+	resolver.resolveLocal(expr.name, expr.depth)
+}
+
+func (resolver *Resolver) visitGetExpr(expr Get) {
+	/*Resolves the object of a property
+	access.
+	*/
+	resolver.resolveExpr(expr.object)
+}
+
+func (resolver *Resolver) visitSetExpr(expr Set) {
+	/*Resolves the value and object of
+	a property assignment.
+	*/
+	resolver.resolveExpr(expr.value)
+	resolver.resolveExpr(expr.object)
+}
+
+func (resolver *Resolver) visitThisExpr(expr This) {
+	/*Resolves 'this' like a local
+	variable of the enclosing method.
+	*/
+	if resolver.currentClass == NO_CLASS {
+		resolver.error(expr.keyword, "Can't use 'this' outside of a class")
+		return
+	}
+	resolver.resolveLocal(expr.keyword, expr.depth)
+}
+
+func (resolver *Resolver) visitSuperExpr(expr Super) {
+	/*Resolves 'super' like a local
+	variable of the enclosing class.
+	*/
+	if resolver.currentClass == NO_CLASS {
+		resolver.error(expr.keyword, "Can't use 'super' outside of a class")
+		return
+	} else if resolver.currentClass != IN_SUBCLASS {
+		resolver.error(expr.keyword, "Can't use 'super' in a class with no superclass")
+		return
+	}
+	resolver.resolveLocal(expr.keyword, expr.depth)
+}
+
+func (resolver *Resolver) visitListExpr(expr List) {
+	/*Resolves the elements of a list
+	literal.
+	*/
+	for i := 0; i < len(expr.elements); i++ {
+		resolver.resolveExpr(expr.elements[i])
+	}
+}
+
+func (resolver *Resolver) visitDictExpr(expr Dict) {
+	/*Resolves the keys and values of a
+	dictionary literal.
+	*/
+	for i := 0; i < len(expr.keys); i++ {
+		resolver.resolveExpr(expr.keys[i])
+		resolver.resolveExpr(expr.values[i])
+	}
+}
+
+func (resolver *Resolver) visitLambdaExpr(expr Lambda) {
+	/*Resolves the parameters and body of
+	an anonymous function.
+	*/
+	resolver.resolveFunction(expr.declaration, IN_FUNCTION)
+}
+
+func (resolver *Resolver) visitInterpolationExpr(expr Interpolation) {
+	/*Resolves the parts of an interpolated
+	string.
+	*/
+	for i := 0; i < len(expr.parts); i++ {
+		resolver.resolveExpr(expr.parts[i])
+	}
+}
+
+func (resolver *Resolver) visitIndexExpr(expr Index) {
+	/*Resolves the object and index of
+	an index expression.
+	*/
+	resolver.resolveExpr(expr.object)
+	resolver.resolveExpr(expr.index)
+}
+
+func (resolver *Resolver) visitIndexSetExpr(expr IndexSet) {
+	/*Resolves the value, object and index
+	of an index assignment.
+	*/
+	resolver.resolveExpr(expr.value)
+	resolver.resolveExpr(expr.object)
+	resolver.resolveExpr(expr.index)
+}
@@ -0,0 +1,13 @@
+package engine
+
+type RuntimeReturn struct {
+	value LoxValue
+}
+
+type RuntimeBreak struct {
+	keyword Token
+}
+
+type RuntimeContinue struct {
+	keyword Token
+}
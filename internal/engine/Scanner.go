@@ -0,0 +1,435 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var reservedWords = map[string]TokenType{
+	"and":      AND,
+	"else":     ELSE,
+	"false":    FALSE,
+	"for":      FOR,
+	"fun":      FUN,
+	"if":       IF,
+	"nil":      NIL,
+	"or":       OR,
+	"print":    PRINT,
+	"return":   RETURN,
+	"true":     TRUE,
+	"var":      VAR,
+	"while":    WHILE,
+	"const":    CONST,
+	"class":    CLASS,
+	"this":     THIS,
+	"super":    SUPER,
+	"break":    BREAK,
+	"continue": CONTINUE,
+	"try":      TRY,
+	"catch":    CATCH,
+	"finally":  FINALLY,
+	"throw":    THROW,
+	"import":   IMPORT,
+}
+
+type Scanner struct {
+	source         *Source
+	srcCode        string
+	start          int
+	currIndex      int
+	line           int
+	lineStart      int
+	interpolations []int
+	unterminated   bool
+	errors         []Diagnostic
+}
+
+func (scnr *Scanner) init(source *Source) {
+	/*Initializes a new Scanner
+	type.
+	*/
+	scnr.source = source
+	scnr.srcCode = source.text
+	scnr.start = 0
+	scnr.currIndex = 0
+	scnr.line = 1
+	scnr.lineStart = 0
+	scnr.interpolations = []int{}
+	scnr.unterminated = false
+	scnr.errors = []Diagnostic{}
+}
+
+func (scnr *Scanner) runScanner() []Token {
+	/*Runs scanner and returns an
+	array of tokens to be used by
+	the parser.
+	*/
+	defer func() {
+		//Adds the error message to the errors
+		//of the scanner when an exception is catched.
+		if r := recover(); r != nil {
+			scnr.errors = append(scnr.errors, r.(Diagnostic))
+		}
+	}()
+	var tokenArr []Token = []Token{}
+	for !scnr.atEnd() {
+		scnr.start = scnr.currIndex
+		if token, isToken := scnr.scanToken(); isToken {
+			tokenArr = append(tokenArr, token)
+		}
+	}
+	if len(scnr.interpolations) > 0 {
+		scnr.unterminated = true
+		scnr.error(scnr.currIndex, "Unterminated string interpolation.")
+	}
+	scnr.start = scnr.currIndex
+	tokenArr = append(tokenArr, scnr.getToken(EOF, ""))
+
+	return tokenArr
+}
+
+func (scnr *Scanner) scanToken() (Token, bool) {
+	/*Scans the lexeme starting at the
+	current index and returns its token.
+	Returns false when the lexeme is
+	whitespace or a comment.
+	*/
+	var SINGLE_LEXEMES = map[string]TokenType{
+		"(": LEFT_PAREN,
+		")": RIGHT_PAREN,
+		"{": LEFT_BRACE,
+		"}": RIGHT_BRACE,
+		"[": LEFT_BRACKET,
+		"]": RIGHT_BRACKET,
+		",": COMMA,
+		":": COLON,
+		".": DOT,
+		"-": MINUS,
+		"+": PLUS,
+		";": SEMICOLON,
+		"*": STAR,
+		"%": MOD,
+	}
+	var OPERATOR_LEXEMES = map[string]TokenType{
+		"!=": NOT_EQUAL,
+		"!":  NOT,
+		"==": EQUAL_EQUAL,
+		"=":  EQUAL,
+		"=>": ARROW,
+		"<":  LESS_THAN,
+		"<=": LESS_EQUAL,
+		">":  GREATER_THAN,
+		">=": GREATER_EQUAL,
+	}
+	currChar := scnr.advance()
+
+	if len(scnr.interpolations) > 0 && (currChar == "{" || currChar == "}") {
+		//Braces are counted inside an interpolated expression
+		//so the '}' that closes it can be told apart.
+		depth := len(scnr.interpolations) - 1
+		if currChar == "{" {
+			scnr.interpolations[depth]++
+		} else if scnr.interpolations[depth] > 0 {
+			scnr.interpolations[depth]--
+		} else {
+			scnr.interpolations = scnr.interpolations[:depth]
+			return scnr.getString(), true
+		}
+	}
+
+	if scnr.inMap(SINGLE_LEXEMES, currChar) {
+		return scnr.getToken(SINGLE_LEXEMES[currChar], currChar), true
+	} else if scnr.inMap(OPERATOR_LEXEMES, currChar) {
+		nextChar := scnr.peek()
+		if scnr.inMap(OPERATOR_LEXEMES, currChar+nextChar) {
+			scnr.advance()
+			return scnr.getToken(OPERATOR_LEXEMES[currChar+nextChar], currChar+nextChar), true
+		}
+		return scnr.getToken(OPERATOR_LEXEMES[currChar], currChar), true
+	} else if currChar == "\n" {
+		scnr.newLine()
+		return Token{}, false
+	} else if scnr.isIgnorable(currChar) {
+		return Token{}, false
+	} else if currChar == "/" {
+		if scnr.peek() == "/" {
+			for !scnr.atEnd() && scnr.peek() != "\n" {
+				scnr.advance()
+			}
+			return Token{}, false
+		}
+		return scnr.getToken(SLASH, currChar), true
+	} else if currChar == "\"" {
+		return scnr.getString(), true
+	} else if scnr.isDigit(currChar) {
+		return scnr.getNumber(), true
+	} else if scnr.isAlpha(currChar) {
+		return scnr.getIdentifier(), true
+	} else {
+		char, _ := utf8.DecodeRuneInString(scnr.srcCode[scnr.start:])
+		scnr.error(scnr.start, "Unexpected character '"+string(char)+"'.")
+		return Token{}, false
+	}
+}
+
+func (scnr *Scanner) getString() Token {
+	/*Scans a string literal that may span
+	several lines. Escape sequences are
+	replaced by the characters they stand
+	for. The token keeps the line where the
+	string starts. When '${' is found the
+	text so far is returned as an
+	INTERPOLATION token and the scanner goes
+	back to scanning the embedded expression.
+	*/
+	startLine := scnr.line
+	startColumn := scnr.getColumn()
+	var value strings.Builder
+
+	for !scnr.atEnd() && scnr.peek() != "\"" {
+		if scnr.peek() == "$" && scnr.peekNext() == "{" {
+			scnr.advance()
+			scnr.advance()
+			scnr.interpolations = append(scnr.interpolations, 0)
+			lexeme := scnr.srcCode[scnr.start:scnr.currIndex]
+			return Token{line: startLine, column: startColumn, offset: scnr.start, source: scnr.source, tokenType: INTERPOLATION, lexeme: lexeme, literal: value.String()}
+		}
+		currChar := scnr.advance()
+		if currChar == "\n" {
+			scnr.newLine()
+			value.WriteString(currChar)
+		} else if currChar == "\\" {
+			value.WriteString(scnr.getEscapeSequence())
+		} else {
+			value.WriteString(currChar)
+		}
+	}
+	if scnr.atEnd() {
+		scnr.unterminated = true
+		scnr.error(scnr.start, "Unterminated string.")
+	}
+	scnr.advance()
+
+	lexeme := scnr.srcCode[scnr.start:scnr.currIndex]
+	return Token{line: startLine, column: startColumn, offset: scnr.start, source: scnr.source, tokenType: STRING, lexeme: lexeme, literal: value.String()}
+}
+
+func (scnr *Scanner) getEscapeSequence() string {
+	/*Returns the character represented by
+	the escape sequence following a backslash.
+	*/
+	var ESCAPE_SEQUENCES = map[string]string{
+		"n":  "\n",
+		"t":  "\t",
+		"r":  "\r",
+		"0":  "\x00",
+		"\"": "\"",
+		"\\": "\\",
+		"$":  "$",
+	}
+	if scnr.atEnd() {
+		scnr.unterminated = true
+		scnr.error(scnr.start, "Unterminated string.")
+	}
+	currChar := scnr.advance()
+
+	if escaped, isEscape := ESCAPE_SEQUENCES[currChar]; isEscape {
+		return escaped
+	} else if currChar == "u" {
+		return scnr.getUnicodeEscape()
+	} else {
+		char, _ := utf8.DecodeRuneInString(scnr.srcCode[scnr.currIndex-1:])
+		scnr.error(scnr.currIndex-2, "Invalid escape sequence '\\"+string(char)+"'.")
+		return ""
+	}
+}
+
+func (scnr *Scanner) getUnicodeEscape() string {
+	/*Returns the character of a unicode
+	escape. Accepts exactly four hex digits
+	as in \u00E9 or up to six hex digits
+	between braces as in \u{1F600}. Surrogate
+	halves are not characters on their own
+	and are rejected.
+	*/
+	var digits string
+	if scnr.peek() == "{" {
+		scnr.advance()
+		for !scnr.atEnd() && scnr.peek() != "}" && len(digits) <= 6 {
+			digits += scnr.advance()
+		}
+		if scnr.peek() != "}" || len(digits) == 0 || len(digits) > 6 {
+			scnr.error(scnr.currIndex, "Invalid unicode escape sequence.")
+		}
+		scnr.advance()
+	} else {
+		for i := 0; i < 4 && !scnr.atEnd(); i++ {
+			digits += scnr.advance()
+		}
+		if len(digits) != 4 {
+			scnr.error(scnr.currIndex, "Invalid unicode escape sequence.")
+		}
+	}
+
+	codePoint, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || codePoint > 0x10FFFF || (codePoint >= 0xD800 && codePoint <= 0xDFFF) {
+		scnr.error(scnr.currIndex, "Invalid unicode escape sequence.")
+	}
+	return string(rune(codePoint))
+}
+
+func (scnr *Scanner) getNumber() Token {
+	/*Scans a number literal. A decimal point
+	is only part of the number when it is
+	followed by a digit.
+	*/
+	decimalFound := false
+	for !scnr.atEnd() {
+		if scnr.isDigit(scnr.peek()) {
+			scnr.advance()
+		} else if scnr.peek() == "." && scnr.isDigit(scnr.peekNext()) {
+			if decimalFound {
+				scnr.error(scnr.currIndex, "Number cannot have two decimals.")
+			}
+			decimalFound = true
+			scnr.advance()
+		} else {
+			break
+		}
+	}
+
+	return scnr.getToken(NUMBER, scnr.srcCode[scnr.start:scnr.currIndex])
+}
+
+func (scnr *Scanner) getIdentifier() Token {
+	/*Scans a word and determines whether
+	it is a reserved word or identifier.
+	*/
+	for !scnr.atEnd() && (scnr.isAlpha(scnr.peek()) || scnr.isDigit(scnr.peek())) {
+		scnr.advance()
+	}
+	word := scnr.srcCode[scnr.start:scnr.currIndex]
+
+	return scnr.getToken(scnr.getIdentifierType(word), word)
+}
+
+func (scnr *Scanner) advance() string {
+	/*Returns the current character
+	and moves the scanner to the
+	next one.
+	*/
+	currChar := scnr.srcCode[scnr.currIndex : scnr.currIndex+1]
+	scnr.currIndex++
+	return currChar
+}
+
+func (scnr *Scanner) peek() string {
+	/*Returns the current character
+	without consuming it. Returns an
+	empty string at the end of the source.
+	*/
+	if scnr.atEnd() {
+		return ""
+	}
+	return scnr.srcCode[scnr.currIndex : scnr.currIndex+1]
+}
+
+func (scnr *Scanner) peekNext() string {
+	/*Returns the character after the
+	current one without consuming it.
+	*/
+	if scnr.currIndex+1 >= len(scnr.srcCode) {
+		return ""
+	}
+	return scnr.srcCode[scnr.currIndex+1 : scnr.currIndex+2]
+}
+
+func (scnr *Scanner) atEnd() bool {
+	/*Returns true if the scanner has
+	consumed the whole source code.
+	*/
+	return scnr.currIndex >= len(scnr.srcCode)
+}
+
+func (scnr *Scanner) getToken(tknType TokenType, lexeme string) Token {
+	/*Constructs a new token object
+	on the current line with given
+	values and returns it.
+	*/
+	return Token{tokenType: tknType, line: scnr.line, column: scnr.getColumn(), offset: scnr.start, source: scnr.source, lexeme: lexeme}
+}
+
+func (scnr *Scanner) newLine() {
+	/*Moves the scanner to the next line
+	after a new line character is consumed.
+	*/
+	scnr.line++
+	scnr.lineStart = scnr.currIndex
+}
+
+func (scnr *Scanner) getColumn() int {
+	/*Returns the column where the current
+	lexeme starts, counting characters from 1.
+	*/
+	return utf8.RuneCountInString(scnr.srcCode[scnr.lineStart:scnr.start]) + 1
+}
+
+func (scnr *Scanner) inMap(mapToSearch map[string]TokenType, currChar string) bool {
+	/*Determines whether
+	a character is in the given
+	map.
+	*/
+	_, isInMap := mapToSearch[currChar]
+	return isInMap
+}
+
+func (scnr *Scanner) isIgnorable(char string) bool {
+	/*Determines whether a given
+	character is an ignorable character.
+	*/
+	var IGNORE_CHARS = []string{
+		" ", "\r", "\t", "\n", "",
+	}
+	for i := 0; i < len(IGNORE_CHARS); i++ {
+		if IGNORE_CHARS[i] == char {
+			return true
+		}
+	}
+	return false
+}
+
+func (scnr *Scanner) error(offset int, message string) {
+	/*Creates a diagnostic pointing at the
+	given offset and then throws an exception
+	when a lox error is found.
+	*/
+	panic(newDiagnostic(COMPILE_ERROR, scnr.source, offset, 1, message))
+}
+
+func (scnr *Scanner) isDigit(char string) bool {
+	/*Determines whether a given
+	character is a digit.
+	*/
+	return char >= "0" && char <= "9"
+}
+
+func (scnr *Scanner) isAlpha(char string) bool {
+	/*Determines whether a given
+	character is alphabetic.
+	*/
+	return (char >= "a" && char <= "z") || (char >= "A" && char <= "Z")
+}
+
+func (scnr *Scanner) getIdentifierType(word string) TokenType {
+	/*Determines whether a word
+	is a reserved word or identifier
+	and returns the appropriate token
+	type.
+	*/
+	if scnr.inMap(reservedWords, word) {
+		return reservedWords[word]
+	} else {
+		return IDENTIFIER
+	}
+}
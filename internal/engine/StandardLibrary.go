@@ -0,0 +1,250 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const VARIADIC_ARITY = -1
+
+type NativeFunction struct {
+	name       string
+	paramCount int
+	capability Capability
+	function   func(interpreter Interpreter, arguments []LoxValue) LoxValue
+}
+
+func (native *NativeFunction) arity() int {
+	/*Returns the number of arguments
+	the native function expects, or
+	VARIADIC_ARITY if it takes any number.
+	*/
+	return native.paramCount
+}
+
+func (native *NativeFunction) call(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Calls the Go function backing
+	the native function. Fails if the
+	function needs a capability that was
+	not granted to the interpreter.
+	*/
+	if native.capability != "" && !interpreter.capabilities[native.capability] {
+		panic(FunctionException{message: deniedMessage(native.capability, fmt.Sprintf("call '%s'", native.name))})
+	}
+	return native.function(interpreter, arguments)
+}
+
+func (native *NativeFunction) String() string {
+	/*Returns a string representation
+	of a native function in lox.
+	*/
+	return "<native fn>"
+}
+
+func standardLibrary() []*NativeFunction {
+	/*Returns the built-in functions
+	defined in every environment. Functions
+	that reach outside of the program name
+	the capability they need.
+	*/
+	return []*NativeFunction{
+		{name: "clock", paramCount: 0, capability: TIME_CAPABILITY, function: clockNative},
+		{name: "toString", paramCount: 1, function: toStringNative},
+		{name: "input", paramCount: 0, capability: STDIN_CAPABILITY, function: inputNative},
+		{name: "parseString", paramCount: 2, function: parseStringNative},
+		{name: "isInstance", paramCount: 2, function: isInstanceNative},
+		{name: "keys", paramCount: 1, function: keysNative},
+		{name: "hasKey", paramCount: 2, function: hasKeyNative},
+		{name: "delete", paramCount: 2, function: deleteNative},
+	}
+}
+
+func clockNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Returns the time represented as the
+	number of seconds since epoch.
+	*/
+	now := time.Now()
+	return now.Unix()
+}
+
+func toStringNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Converts any lox type to its
+	string representation.
+	*/
+	return interpreter.stringify(arguments[0])
+}
+
+func inputNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Prompts user for input. Reads a line
+	from the standard input of the interpreter.
+	The last line of the input doesn't need to
+	end with a new line character.
+	*/
+	userInput, err := interpreter.stdin.ReadString('\n')
+	userInput = strings.TrimSuffix(strings.TrimSuffix(userInput, "\n"), "\r")
+	if err == nil || userInput != "" {
+		return userInput
+	} else {
+		return ""
+	}
+}
+
+func parseStringNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Parses a string
+	given the lox type.
+	*/
+	typeStr, typeIsString := arguments[0].(string)
+	valueStr, valueIsString := arguments[1].(string)
+
+	if typeIsString && valueIsString {
+		switch typeStr {
+		case "int":
+			{
+				valueInt, err := strconv.ParseInt(valueStr, 10, 64)
+				if err == nil {
+					return valueInt
+				} else {
+					panic(FunctionException{message: fmt.Sprintf("Cannot convert '%s' to int.", valueStr)})
+				}
+			}
+		case "float":
+			{
+				valueFloat, err := strconv.ParseFloat(valueStr, 64)
+				if err == nil {
+					return valueFloat
+				} else {
+					panic(FunctionException{message: fmt.Sprintf("Cannot convert '%s' to float.", valueStr)})
+				}
+			}
+		case "bool":
+			{
+				valueBool, err := strconv.ParseBool(valueStr)
+				if err == nil {
+					return valueBool
+				} else {
+					panic(FunctionException{message: fmt.Sprintf("Cannot convert '%s' to boolean.", valueStr)})
+				}
+			}
+		case "string":
+			{
+				return valueStr
+			}
+		default:
+			panic(FunctionException{message: fmt.Sprintf("Type '%s' is not supported.", typeStr)})
+		}
+	} else {
+		panic(FunctionException{message: fmt.Sprintf("Arguments are not strings.")})
+	}
+}
+
+func isInstanceNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Determines whether a
+	given type is the given value.
+	*/
+	typeStr, typeIsString := arguments[0].(string)
+	if typeIsString {
+		switch typeStr {
+		case "int":
+			{
+				_, valueIsInt := arguments[1].(int64)
+				return valueIsInt
+			}
+		case "float":
+			{
+				_, valueIsFloat := arguments[1].(float64)
+				return valueIsFloat
+			}
+		case "boolean":
+			{
+				_, valueIsBool := arguments[1].(bool)
+				return valueIsBool
+			}
+		case "string":
+			{
+				_, valueIsString := arguments[1].(string)
+				return valueIsString
+			}
+		case "function":
+			{
+				_, valueIsFunc := arguments[1].(LoxCallable)
+				return valueIsFunc
+			}
+		case "list":
+			{
+				_, valueIsList := arguments[1].(*LoxList)
+				return valueIsList
+			}
+		case "dict":
+			{
+				_, valueIsDict := arguments[1].(*LoxDict)
+				return valueIsDict
+			}
+		case "error":
+			{
+				_, valueIsError := arguments[1].(*ErrorValue)
+				return valueIsError
+			}
+		case "module":
+			{
+				_, valueIsModule := arguments[1].(*LoxModule)
+				return valueIsModule
+			}
+		case "class":
+			{
+				_, valueIsClass := arguments[1].(*LoxClass)
+				return valueIsClass
+			}
+		case "instance":
+			{
+				_, valueIsInstance := arguments[1].(*LoxInstance)
+				return valueIsInstance
+			}
+		default:
+			{
+				panic(FunctionException{message: fmt.Sprintf("Type '%s' is not supported.", typeStr)})
+			}
+		}
+	} else {
+		panic(FunctionException{message: "Type argument must be string."})
+	}
+}
+
+func keysNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Returns a list with the keys of a
+	dictionary in insertion order.
+	*/
+	dict, isDict := arguments[0].(*LoxDict)
+	if !isDict {
+		panic(FunctionException{message: "Argument must be a dictionary."})
+	}
+
+	keys := make([]LoxValue, len(dict.keys))
+	copy(keys, dict.keys)
+	return &LoxList{elements: keys}
+}
+
+func hasKeyNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Determines whether a dictionary
+	contains the given key.
+	*/
+	dict, isDict := arguments[0].(*LoxDict)
+	if !isDict {
+		panic(FunctionException{message: "First argument must be a dictionary."})
+	}
+
+	return dict.has(arguments[1])
+}
+
+func deleteNative(interpreter Interpreter, arguments []LoxValue) LoxValue {
+	/*Removes a key from a dictionary and
+	returns the value it had.
+	*/
+	dict, isDict := arguments[0].(*LoxDict)
+	if !isDict {
+		panic(FunctionException{message: "First argument must be a dictionary."})
+	}
+
+	return dict.delete(arguments[1])
+}
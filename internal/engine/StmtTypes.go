@@ -0,0 +1,191 @@
+package engine
+
+type Block struct {
+	statements []Stmt
+}
+
+func (blockObj Block) accept(visitor Interpreter) LoxValue {
+	return visitor.visitBlockStmt(blockObj)
+}
+
+func (blockObj Block) resolve(resolver *Resolver) {
+	resolver.visitBlockStmt(blockObj)
+}
+
+type Expression struct {
+	expression Expr
+}
+
+func (expressionObj Expression) accept(visitor Interpreter) LoxValue {
+	return visitor.visitExpressionStmt(expressionObj)
+}
+
+func (expressionObj Expression) resolve(resolver *Resolver) {
+	resolver.visitExpressionStmt(expressionObj)
+}
+
+type If struct {
+	condition Expr
+	thenBranch Stmt
+	elseBranch Stmt
+}
+
+func (ifObj If) accept(visitor Interpreter) LoxValue {
+	return visitor.visitIfStmt(ifObj)
+}
+
+func (ifObj If) resolve(resolver *Resolver) {
+	resolver.visitIfStmt(ifObj)
+}
+
+type Print struct {
+	expression Expr
+}
+
+func (printObj Print) accept(visitor Interpreter) LoxValue {
+	return visitor.visitPrintStmt(printObj)
+}
+
+func (printObj Print) resolve(resolver *Resolver) {
+	resolver.visitPrintStmt(printObj)
+}
+
+type While struct {
+	keyword Token
+	condition Expr
+	body Stmt
+	increment Expr
+}
+
+func (whileObj While) accept(visitor Interpreter) LoxValue {
+	return visitor.visitWhileStmt(whileObj)
+}
+
+func (whileObj While) resolve(resolver *Resolver) {
+	resolver.visitWhileStmt(whileObj)
+}
+
+type Var struct {
+	name Token
+	initializer Expr
+	isConst bool
+}
+
+func (varObj Var) accept(visitor Interpreter) LoxValue {
+	return visitor.visitVarStmt(varObj)
+}
+
+func (varObj Var) resolve(resolver *Resolver) {
+	resolver.visitVarStmt(varObj)
+}
+
+type Function struct {
+	name Token
+	params []Token
+	body []Stmt
+}
+
+func (functionObj Function) accept(visitor Interpreter) LoxValue {
+	return visitor.visitFunctionStmt(functionObj)
+}
+
+func (functionObj Function) resolve(resolver *Resolver) {
+	resolver.visitFunctionStmt(functionObj)
+}
+
+type Return struct {
+	keyword Token
+	value Expr
+}
+
+func (returnObj Return) accept(visitor Interpreter) LoxValue {
+	return visitor.visitReturnStmt(returnObj)
+}
+
+func (returnObj Return) resolve(resolver *Resolver) {
+	resolver.visitReturnStmt(returnObj)
+}
+
+type Class struct {
+	name Token
+	superclass Expr
+	methods []Function
+}
+
+func (classObj Class) accept(visitor Interpreter) LoxValue {
+	return visitor.visitClassStmt(classObj)
+}
+
+func (classObj Class) resolve(resolver *Resolver) {
+	resolver.visitClassStmt(classObj)
+}
+
+type Break struct {
+	keyword Token
+}
+
+func (breakObj Break) accept(visitor Interpreter) LoxValue {
+	return visitor.visitBreakStmt(breakObj)
+}
+
+func (breakObj Break) resolve(resolver *Resolver) {
+	resolver.visitBreakStmt(breakObj)
+}
+
+type Continue struct {
+	keyword Token
+}
+
+func (continueObj Continue) accept(visitor Interpreter) LoxValue {
+	return visitor.visitContinueStmt(continueObj)
+}
+
+func (continueObj Continue) resolve(resolver *Resolver) {
+	resolver.visitContinueStmt(continueObj)
+}
+
+type Throw struct {
+	keyword Token
+	value Expr
+}
+
+func (throwObj Throw) accept(visitor Interpreter) LoxValue {
+	return visitor.visitThrowStmt(throwObj)
+}
+
+func (throwObj Throw) resolve(resolver *Resolver) {
+	resolver.visitThrowStmt(throwObj)
+}
+
+type Import struct {
+	keyword Token
+	path Token
+	hasName bool
+	name Token
+}
+
+func (importObj Import) accept(visitor Interpreter) LoxValue {
+	return visitor.visitImportStmt(importObj)
+}
+
+func (importObj Import) resolve(resolver *Resolver) {
+	resolver.visitImportStmt(importObj)
+}
+
+type Try struct {
+	keyword Token
+	body []Stmt
+	hasCatch bool
+	catchName Token
+	catchBody []Stmt
+	finallyBody []Stmt
+}
+
+func (tryObj Try) accept(visitor Interpreter) LoxValue {
+	return visitor.visitTryStmt(tryObj)
+}
+
+func (tryObj Try) resolve(resolver *Resolver) {
+	resolver.visitTryStmt(tryObj)
+}
+
@@ -0,0 +1,135 @@
+package engine
+
+type TokenType int
+
+const (
+	LEFT_PAREN = iota
+	NUMBER
+	CONST
+	RIGHT_PAREN
+	LEFT_BRACE
+	STRING
+	INTERPOLATION
+	RIGHT_BRACE
+	LEFT_BRACKET
+	RIGHT_BRACKET
+	COMMA
+	COLON
+	DOT
+	MINUS
+	PLUS
+	SEMICOLON
+	STAR
+	NOT_EQUAL
+	NOT
+	EQUAL_EQUAL
+	EQUAL
+	ARROW
+	LESS_THAN
+	LESS_EQUAL
+	GREATER_THAN
+	GREATER_EQUAL
+	AND
+	ELSE
+	FALSE
+	FOR
+	FUN
+	IF
+	NIL
+	OR
+	PRINT
+	RETURN
+	TRUE
+	VAR
+	WHILE
+	CLASS
+	THIS
+	SUPER
+	BREAK
+	CONTINUE
+	TRY
+	CATCH
+	FINALLY
+	THROW
+	IMPORT
+	MOD
+	SLASH
+	IDENTIFIER
+	EOF
+)
+
+var tokenTypeNames = []string{
+	"LEFT_PAREN",
+	"NUMBER",
+	"CONST",
+	"RIGHT_PAREN",
+	"LEFT_BRACE",
+	"STRING",
+	"INTERPOLATION",
+	"RIGHT_BRACE",
+	"LEFT_BRACKET",
+	"RIGHT_BRACKET",
+	"COMMA",
+	"COLON",
+	"DOT",
+	"MINUS",
+	"PLUS",
+	"SEMICOLON",
+	"STAR",
+	"NOT_EQUAL",
+	"NOT",
+	"EQUAL_EQUAL",
+	"EQUAL",
+	"ARROW",
+	"LESS_THAN",
+	"LESS_EQUAL",
+	"GREATER_THAN",
+	"GREATER_EQUAL",
+	"AND",
+	"ELSE",
+	"FALSE",
+	"FOR",
+	"FUN",
+	"IF",
+	"NIL",
+	"OR",
+	"PRINT",
+	"RETURN",
+	"TRUE",
+	"VAR",
+	"WHILE",
+	"CLASS",
+	"THIS",
+	"SUPER",
+	"BREAK",
+	"CONTINUE",
+	"TRY",
+	"CATCH",
+	"FINALLY",
+	"THROW",
+	"IMPORT",
+	"MOD",
+	"SLASH",
+	"IDENTIFIER",
+	"EOF",
+}
+
+func (tokenType TokenType) String() string {
+	/*Returns the name of the
+	token type.
+	*/
+	if int(tokenType) < len(tokenTypeNames) {
+		return tokenTypeNames[tokenType]
+	}
+	return "UNKNOWN"
+}
+
+type Token struct {
+	line      int
+	column    int
+	offset    int
+	source    *Source
+	tokenType TokenType
+	lexeme    string
+	literal   LoxValue
+}
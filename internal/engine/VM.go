@@ -0,0 +1,333 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+type VM struct {
+	interpreter Interpreter
+	resolver    Resolver
+}
+
+func NewVM() *VM {
+	/*Returns a new virtual machine
+	with the built-in functions defined.
+	Globals are kept between calls to
+	Eval and RunFile.
+	*/
+	vm := &VM{}
+	vm.interpreter.init(nil)
+	vm.resolver.init()
+	return vm
+}
+
+func (vm *VM) Eval(srcCode string) error {
+	/*Compiles and runs the given source
+	code. Returns a CompileError if the code
+	is not valid and a RuntimeError if it
+	fails while running.
+	*/
+	return vm.EvalContext(context.Background(), srcCode)
+}
+
+func (vm *VM) EvalContext(ctx context.Context, srcCode string) error {
+	/*Compiles and runs the given source code
+	like Eval. The program is stopped with a
+	RuntimeError wrapping the context error
+	when the context is cancelled or its
+	deadline passes.
+	*/
+	return vm.eval(ctx, &Source{name: EVAL_SOURCE, text: srcCode}, false, false)
+}
+
+func (vm *VM) EvalRepl(srcCode string) error {
+	/*Compiles and runs a line entered in the
+	REPL. The last expression needs no semicolon,
+	the values of top level expression statements
+	are printed and top level declarations replace
+	existing bindings.
+	*/
+	return vm.EvalReplContext(context.Background(), srcCode)
+}
+
+func (vm *VM) EvalReplContext(ctx context.Context, srcCode string) error {
+	/*Compiles and runs a line entered in the
+	REPL like EvalRepl. The line is stopped
+	when the context is done.
+	*/
+	return vm.eval(ctx, &Source{name: REPL_SOURCE, text: srcCode}, true, true)
+}
+
+func (vm *VM) eval(ctx context.Context, source *Source, replMode bool, redefineGlobals bool) error {
+	/*Compiles and runs the given source
+	code with the rules of the REPL if
+	replMode is true. Top level declarations
+	replace existing bindings if redefineGlobals
+	is true. Every run gets a
+	full step budget. The constants declared
+	by the code are only remembered if it
+	compiles and runs without errors.
+	*/
+	vm.resolver.scriptPath = vm.interpreter.scriptPath
+	vm.resolver.readModules = vm.interpreter.capabilities[FILESYSTEM_CAPABILITY]
+	vm.resolver.redefineGlobals = redefineGlobals
+	stmtArr, resolver, err := compile(vm.resolver, source, replMode)
+	if err != nil {
+		return err
+	}
+	vm.interpreter.trees = stmtArr
+	vm.interpreter.echo = replMode
+	vm.interpreter.redefineGlobals = redefineGlobals
+	vm.interpreter.limits.reset(ctx)
+	if err := vm.interpreter.interpret(); err != nil {
+		return err
+	}
+	resolver.commitConsts()
+	vm.resolver = resolver
+	return nil
+}
+
+func (vm *VM) RunFile(filePath string) error {
+	/*Reads the whole file of given
+	path and runs it. Imports in the file
+	are resolved relative to its path.
+	*/
+	return vm.RunFileContext(context.Background(), filePath)
+}
+
+func (vm *VM) RunFileContext(ctx context.Context, filePath string) error {
+	/*Reads the whole file of given path
+	and runs it like RunFile. The program
+	is stopped when the context is done.
+	*/
+	srcCode, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	vm.interpreter.setScriptPath(filePath)
+	return vm.eval(ctx, &Source{name: filePath, text: string(srcCode)}, false, false)
+}
+
+func (vm *VM) LoadFile(filePath string) error {
+	/*Reads the whole file of given path and
+	runs it in the current session. Top level
+	declarations replace existing bindings, so
+	a file can be loaded again after editing it.
+	*/
+	return vm.LoadFileContext(context.Background(), filePath)
+}
+
+func (vm *VM) LoadFileContext(ctx context.Context, filePath string) error {
+	/*Reads and runs the file of given path
+	like LoadFile. Imports in the file are
+	resolved relative to its path while it
+	runs, later code keeps resolving them as
+	before. The file is stopped when the
+	context is done.
+	*/
+	srcCode, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	scriptPath, importStack := vm.interpreter.scriptPath, vm.interpreter.importStack
+	defer func() {
+		vm.interpreter.scriptPath, vm.interpreter.importStack = scriptPath, importStack
+	}()
+	vm.interpreter.setScriptPath(filePath)
+	return vm.eval(ctx, &Source{name: filePath, text: string(srcCode)}, false, true)
+}
+
+func (vm *VM) SetMaxSteps(steps int64) {
+	/*Sets how many statements, expressions
+	and loop iterations a single run can take
+	before it is stopped with a RuntimeError
+	wrapping ErrStepLimitExceeded. A value of
+	0 or less removes the limit.
+	*/
+	vm.interpreter.limits.maxSteps = steps
+}
+
+func (vm *VM) SetMaxCallDepth(depth int) {
+	/*Sets how many calls can be nested before
+	a "Stack overflow" runtime error is raised.
+	A depth of 0 or less removes the limit.
+	*/
+	vm.interpreter.maxCallDepth = depth
+}
+
+func (vm *VM) SetCapabilities(capabilities ...Capability) {
+	/*Grants exactly the given capabilities
+	to the programs run by the virtual machine.
+	Natives needing any other capability raise
+	a runtime error when called.
+	*/
+	vm.interpreter.capabilities = capabilitySet(capabilities)
+}
+
+func (vm *VM) UseProfile(name string) error {
+	/*Grants the capabilities of the profile
+	with the given name. The "pure" profile
+	grants none, so programs can't read the
+	clock, stdin or files and always give
+	the same result.
+	*/
+	capabilities, err := profileCapabilities(name)
+	if err != nil {
+		return err
+	}
+	vm.SetCapabilities(capabilities...)
+	return nil
+}
+
+func (vm *VM) Deny(capabilities ...Capability) {
+	/*Takes the given capabilities away from
+	the programs run by the virtual machine.
+	*/
+	for i := 0; i < len(capabilities); i++ {
+		delete(vm.interpreter.capabilities, capabilities[i])
+	}
+}
+
+func (vm *VM) SetStdin(reader io.Reader) {
+	/*Sets where the input function reads
+	lines from. The reader is buffered once
+	and shared by every call, so no input is
+	lost between lines. A *bufio.Reader is
+	used as it is, so the host can keep reading
+	from it too.
+	*/
+	vm.interpreter.stdin = bufio.NewReader(reader)
+}
+
+func (vm *VM) SetStdout(writer io.Writer) {
+	/*Sets where print statements and the
+	values echoed by the REPL are written.
+	*/
+	vm.interpreter.stdout = writer
+}
+
+func (vm *VM) SetStderr(writer io.Writer) {
+	/*Sets where ReportError writes the
+	errors returned by the virtual machine.
+	*/
+	vm.interpreter.stderr = writer
+}
+
+func (vm *VM) Stderr() io.Writer {
+	/*Returns where the errors of the
+	virtual machine are written.
+	*/
+	return vm.interpreter.stderr
+}
+
+func (vm *VM) ReportError(err error) {
+	/*Writes the given error, with its source
+	snippet and traceback, on the standard
+	error of the virtual machine. Nothing is
+	written if the error is nil.
+	*/
+	if err != nil {
+		fmt.Fprintln(vm.interpreter.stderr, err)
+	}
+}
+
+func (vm *VM) Get(name string) (interface{}, error) {
+	/*Returns the value of the global
+	variable with the given name converted
+	to a Go value.
+	*/
+	value, isDefined := vm.interpreter.globals.values[name]
+	if !isDefined {
+		return nil, fmt.Errorf("undefined variable '%s'", name)
+	}
+	return toGoValue(value)
+}
+
+func (vm *VM) Set(name string, value interface{}) error {
+	/*Defines or replaces the global variable
+	with the given name. The Go value is
+	converted to a lox value first.
+	*/
+	if _, isConst := vm.resolver.globalConsts[name]; isConst {
+		return fmt.Errorf("cannot reassign constant variable '%s'", name)
+	}
+	loxValue, err := toLoxValue(value)
+	if err != nil {
+		return err
+	}
+	vm.interpreter.globals.define(name, loxValue)
+	return nil
+}
+
+func (vm *VM) RegisterFunction(name string, arity int, function func(arguments []interface{}) (interface{}, error)) {
+	/*Defines a global native function backed
+	by the given Go function. Arguments and the
+	returned value are converted between Go and
+	lox values. Use VARIADIC_ARITY to accept any
+	number of arguments. A returned error or a
+	panic becomes a runtime error at the call site.
+	*/
+	vm.interpreter.registerNative(&NativeFunction{name: name, paramCount: arity, function: func(interpreter Interpreter, arguments []LoxValue) LoxValue {
+		goArguments := make([]interface{}, len(arguments))
+		for i := 0; i < len(arguments); i++ {
+			goArgument, err := toGoValue(arguments[i])
+			if err != nil {
+				panic(FunctionException{message: err.Error()})
+			}
+			goArguments[i] = goArgument
+		}
+
+		result, err := callHostFunction(name, function, goArguments)
+		if err != nil {
+			panic(FunctionException{message: err.Error()})
+		}
+		loxResult, err := toLoxValue(result)
+		if err != nil {
+			panic(FunctionException{message: err.Error()})
+		}
+		return loxResult
+	}})
+}
+
+func callHostFunction(name string, function func(arguments []interface{}) (interface{}, error), arguments []interface{}) (result interface{}, err error) {
+	/*Calls a function registered by the host.
+	A panic inside the function is returned
+	as an error, so it becomes a runtime error
+	in the program instead of crashing the host.
+	*/
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Function '%s' panicked: %v", name, r)
+		}
+	}()
+	return function(arguments)
+}
+
+func (vm *VM) Globals() []string {
+	/*Returns the names of the global
+	variables in alphabetical order.
+	*/
+	names := make([]string, 0, len(vm.interpreter.globals.values))
+	for name := range vm.interpreter.globals.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (vm *VM) Inspect(name string) (string, bool) {
+	/*Returns the value of the global
+	variable with the given name as it
+	would be printed by lox.
+	*/
+	value, isDefined := vm.interpreter.globals.values[name]
+	if !isDefined {
+		return "", false
+	}
+	return vm.interpreter.stringify(value), true
+}
@@ -0,0 +1,91 @@
+package lox
+
+import (
+	"workspace/internal/engine"
+)
+
+type VM = engine.VM
+
+type Diagnostic = engine.Diagnostic
+
+type CompileError = engine.CompileError
+
+type RuntimeError = engine.RuntimeError
+
+type CallFrame = engine.CallFrame
+
+type Capability = engine.Capability
+
+const (
+	COMPILE_ERROR = engine.COMPILE_ERROR
+	RUNTIME_ERROR = engine.RUNTIME_ERROR
+)
+
+const (
+	TIME_CAPABILITY       = engine.TIME_CAPABILITY
+	STDIN_CAPABILITY      = engine.STDIN_CAPABILITY
+	FILESYSTEM_CAPABILITY = engine.FILESYSTEM_CAPABILITY
+)
+
+const (
+	FULL_PROFILE = engine.FULL_PROFILE
+	PURE_PROFILE = engine.PURE_PROFILE
+)
+
+const DEFAULT_MAX_CALL_DEPTH = engine.DEFAULT_MAX_CALL_DEPTH
+
+const VARIADIC_ARITY = engine.VARIADIC_ARITY
+
+var ErrStepLimitExceeded = engine.ErrStepLimitExceeded
+
+func NewVM() *VM {
+	/*Returns a new virtual machine
+	with the built-in functions defined.
+	Globals are kept between calls to
+	Eval and RunFile.
+	*/
+	return engine.NewVM()
+}
+
+func ParseCapability(name string) (Capability, error) {
+	/*Returns the capability with the given
+	name. Fails if there is no such capability.
+	*/
+	return engine.ParseCapability(name)
+}
+
+func Profiles() []string {
+	/*Returns the names of the capability
+	profiles in alphabetical order.
+	*/
+	return engine.Profiles()
+}
+
+func IsIncomplete(srcCode string) bool {
+	/*Determines if the given source code
+	ends in the middle of a statement, so
+	the REPL should keep reading lines.
+	*/
+	return engine.IsIncomplete(srcCode)
+}
+
+func Keywords() []string {
+	/*Returns the reserved words of
+	the language in alphabetical order.
+	*/
+	return engine.Keywords()
+}
+
+func FormatTokens(srcCode string) (string, error) {
+	/*Returns the tokens of the given
+	source code, one per line.
+	*/
+	return engine.FormatTokens(srcCode)
+}
+
+func FormatAST(srcCode string) (string, error) {
+	/*Returns the syntax tree of the
+	given source code.
+	*/
+	return engine.FormatAST(srcCode)
+}
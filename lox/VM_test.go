@@ -0,0 +1,429 @@
+package lox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const INFINITE_LOOP = "while (true) {}"
+
+func TestStepLimitStopsProgram(t *testing.T) {
+	vm := NewVM()
+	vm.SetMaxSteps(1000)
+
+	err := vm.Eval(INFINITE_LOOP)
+	if !errors.Is(err, ErrStepLimitExceeded) {
+		t.Fatalf("expected the step limit to stop the loop, got %v", err)
+	}
+	runtimeErr, isRuntimeErr := err.(*RuntimeError)
+	if !isRuntimeErr || runtimeErr.Message != "Step limit exceeded" || runtimeErr.Line != 1 {
+		t.Errorf("unexpected runtime error %#v", err)
+	}
+
+	if err := vm.Eval("var done = true;"); err != nil {
+		t.Errorf("expected a new run to get a full step budget, got %v", err)
+	}
+}
+
+func TestDeadlineStopsProgram(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := NewVM().EvalContext(ctx, INFINITE_LOOP)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to stop the loop, got %v", err)
+	}
+	if err.(*RuntimeError).Message != "Execution timed out" {
+		t.Errorf("unexpected message %q", err.(*RuntimeError).Message)
+	}
+}
+
+func TestCancelFromAnotherGoroutine(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err := NewVM().EvalContext(ctx, INFINITE_LOOP)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancellation to stop the loop, got %v", err)
+	}
+	if err.(*RuntimeError).Message != "Execution cancelled" {
+		t.Errorf("unexpected message %q", err.(*RuntimeError).Message)
+	}
+}
+
+func TestCatchCannotStopInterrupt(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	vm := NewVM()
+
+	err := vm.EvalContext(ctx, `
+var caught = false;
+try {
+    while (true) {}
+} catch (e) {
+    caught = true;
+}`)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to stop the program, got %v", err)
+	}
+	if caught, _ := vm.Get("caught"); caught != false {
+		t.Errorf("expected the catch block not to run, caught is %v", caught)
+	}
+}
+
+func TestFinallySkippedOnInterrupt(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	vm := NewVM()
+
+	err := vm.EvalContext(ctx, `
+var cleaned = false;
+try {
+    while (true) {}
+} finally {
+    cleaned = true;
+}`)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to stop the program, got %v", err)
+	}
+	if cleaned, _ := vm.Get("cleaned"); cleaned != false {
+		t.Errorf("expected the finally block to be skipped, cleaned is %v", cleaned)
+	}
+}
+
+func TestPureProfileDeniesCapabilities(t *testing.T) {
+	programs := map[string]string{
+		"clock();":                       "Cannot call 'clock': capability 'time' is not granted",
+		"input();":                       "Cannot call 'input': capability 'stdin' is not granted",
+		`import "modules/shapes.lox";`:   "Cannot import 'modules/shapes.lox': capability 'filesystem' is not granted",
+		`import m from "modules/m.lox";`: "Cannot import 'modules/m.lox': capability 'filesystem' is not granted",
+	}
+	for program, message := range programs {
+		vm := NewVM()
+		if err := vm.UseProfile(PURE_PROFILE); err != nil {
+			t.Fatal(err)
+		}
+		err := vm.Eval(program)
+		runtimeErr, isRuntimeErr := err.(*RuntimeError)
+		if !isRuntimeErr || runtimeErr.Message != message {
+			t.Errorf("%s: expected %q, got %v", program, message, err)
+		}
+	}
+
+	if err := NewVM().UseProfile("unknown"); err == nil {
+		t.Error("expected an unknown profile to be rejected")
+	}
+}
+
+func TestDenyRemovesOneCapability(t *testing.T) {
+	vm := NewVM()
+	vm.SetStdin(strings.NewReader("line\n"))
+	vm.Deny(TIME_CAPABILITY)
+
+	err := vm.Eval("clock();")
+	if runtimeErr, isRuntimeErr := err.(*RuntimeError); !isRuntimeErr || !strings.Contains(runtimeErr.Message, "capability 'time' is not granted") {
+		t.Errorf("expected clock to be denied, got %v", err)
+	}
+	if err := vm.Eval("var read = input();"); err != nil {
+		t.Fatalf("expected input to stay granted, got %v", err)
+	}
+	if read, _ := vm.Get("read"); read != "line" {
+		t.Errorf("expected input to read the line, got %v", read)
+	}
+}
+
+func TestScriptedInputAndCapturedOutput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	vm := NewVM()
+	vm.SetStdin(strings.NewReader("first\nsecond\r\nthird"))
+	vm.SetStdout(&stdout)
+	vm.SetStderr(&stderr)
+
+	err := vm.Eval(`
+for (var i = 0; i < 4; i = i + 1) {
+    print "read: " + input();
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "read: first\nread: second\nread: third\nread: \n"
+	if stdout.String() != expected {
+		t.Errorf("expected output %q, got %q", expected, stdout.String())
+	}
+
+	vm.ReportError(vm.Eval("print nil + 1;"))
+	if !strings.Contains(stderr.String(), "runtime error: Operands must be two numbers or two strings.") {
+		t.Errorf("expected the error on the captured stderr, got %q", stderr.String())
+	}
+}
+
+func TestGetSelfContainingValues(t *testing.T) {
+	vm := NewVM()
+	if err := vm.Eval(`var xs = [1]; xs[0] = xs; var d = {}; d["self"] = d;`); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"xs", "d"} {
+		if value, err := vm.Get(name); err == nil || !strings.Contains(err.Error(), "contains itself") {
+			t.Errorf("%s: expected a conversion error, got %v, %v", name, value, err)
+		}
+	}
+
+	vm.RegisterFunction("size", 1, func(arguments []interface{}) (interface{}, error) {
+		return len(arguments[0].([]interface{})), nil
+	})
+	err := vm.Eval("size(xs);")
+	if runtimeErr, isRuntimeErr := err.(*RuntimeError); !isRuntimeErr || !strings.Contains(runtimeErr.Message, "contains itself") {
+		t.Errorf("expected a runtime error for the argument, got %v", err)
+	}
+	if err := vm.Eval(`var shared = [1]; var pair = [shared, shared];`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.Get("pair"); err != nil {
+		t.Errorf("expected a list that repeats another list to convert, got %v", err)
+	}
+}
+
+func TestSetMapKeepsSortedKeys(t *testing.T) {
+	var stdout bytes.Buffer
+	vm := NewVM()
+	vm.SetStdout(&stdout)
+
+	values := map[string]int{"b": 2, "c": 3, "a": 1, "d": 4}
+	numbers := map[interface{}]string{10: "ten", 9: "nine", "x": "ex", 1.5: "one and a half"}
+	for i := 0; i < 10; i++ {
+		if err := vm.Set("values", values); err != nil {
+			t.Fatal(err)
+		}
+		if err := vm.Set("numbers", numbers); err != nil {
+			t.Fatal(err)
+		}
+		if err := vm.Eval("print values; print numbers;"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := strings.Repeat(`{"a": 1, "b": 2, "c": 3, "d": 4}`+"\n"+`{1.500000: "one and a half", 9: "nine", 10: "ten", "x": "ex"}`+"\n", 10)
+	if stdout.String() != expected {
+		t.Errorf("expected keys in sorted order, got %q", stdout.String())
+	}
+}
+
+func TestPanicInRegisteredFunction(t *testing.T) {
+	vm := NewVM()
+	vm.RegisterFunction("broken", 0, func(arguments []interface{}) (interface{}, error) {
+		var counts map[string]int
+		counts["calls"]++
+		return nil, nil
+	})
+
+	err := vm.Eval("broken();")
+	runtimeErr, isRuntimeErr := err.(*RuntimeError)
+	if !isRuntimeErr || !strings.HasPrefix(runtimeErr.Message, "Function 'broken' panicked:") || runtimeErr.Line != 1 {
+		t.Fatalf("expected a runtime error at the call, got %v", err)
+	}
+
+	if err := vm.Eval(`var message; try { broken(); } catch (e) { message = "caught"; }`); err != nil {
+		t.Fatal(err)
+	}
+	if message, _ := vm.Get("message"); message != "caught" {
+		t.Errorf("expected the panic to be catchable, message is %v", message)
+	}
+}
+
+func TestEvalErrorTypes(t *testing.T) {
+	vm := NewVM()
+
+	err := vm.Eval("var = 1;\nprint (;")
+	compileErr, isCompileErr := err.(*CompileError)
+	if !isCompileErr || len(compileErr.Diagnostics) != 2 {
+		t.Fatalf("expected a compile error with two diagnostics, got %#v", err)
+	}
+	for _, diagnostic := range compileErr.Diagnostics {
+		if diagnostic.Kind != COMPILE_ERROR {
+			t.Errorf("expected a %s diagnostic, got %#v", COMPILE_ERROR, diagnostic)
+		}
+	}
+
+	err = vm.Eval("var a = 1;\nprint a + nil;")
+	runtimeErr, isRuntimeErr := err.(*RuntimeError)
+	if !isRuntimeErr || runtimeErr.Kind != RUNTIME_ERROR || runtimeErr.Line != 2 {
+		t.Fatalf("expected a runtime error on line 2, got %#v", err)
+	}
+	if a, _ := vm.Get("a"); a != int64(1) {
+		t.Errorf("expected globals defined before the error to be kept, a is %v", a)
+	}
+}
+
+func TestRunFileErrorTypes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	programs := map[string]string{
+		"compile.lox": "print ;",
+		"runtime.lox": "\nprint -\"a\";",
+	}
+	for name, program := range programs {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(program), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = NewVM().RunFile(filepath.Join(dir, "compile.lox"))
+	if compileErr, isCompileErr := err.(*CompileError); !isCompileErr || compileErr.Diagnostics[0].File != filepath.Join(dir, "compile.lox") {
+		t.Errorf("expected a compile error in compile.lox, got %#v", err)
+	}
+	err = NewVM().RunFile(filepath.Join(dir, "runtime.lox"))
+	if runtimeErr, isRuntimeErr := err.(*RuntimeError); !isRuntimeErr || runtimeErr.File != filepath.Join(dir, "runtime.lox") || runtimeErr.Line != 2 {
+		t.Errorf("expected a runtime error on line 2 of runtime.lox, got %#v", err)
+	}
+	err = NewVM().RunFile(filepath.Join(dir, "missing.lox"))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected a missing file error, got %#v", err)
+	}
+}
+
+func TestGetAndSetRoundTrip(t *testing.T) {
+	vm := NewVM()
+	values := map[string]interface{}{
+		"flag":   true,
+		"count":  int64(3),
+		"ratio":  0.5,
+		"name":   "lox",
+		"absent": nil,
+	}
+	for name, value := range values {
+		if err := vm.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := vm.Eval("count = count + 1; name = name + \"!\";"); err != nil {
+		t.Fatal(err)
+	}
+	values["count"] = int64(4)
+	values["name"] = "lox!"
+	for name, expected := range values {
+		if value, err := vm.Get(name); err != nil || value != expected {
+			t.Errorf("%s: expected %v, got %v, %v", name, expected, value, err)
+		}
+	}
+
+	if _, err := vm.Get("undefined"); err == nil {
+		t.Error("expected an undefined variable to be reported")
+	}
+	if err := vm.Set("channel", make(chan int)); err == nil {
+		t.Error("expected a channel to be rejected")
+	}
+	if err := vm.Set("huge", uint64(1<<63)); err == nil {
+		t.Error("expected an unsigned integer that doesn't fit an int to be rejected")
+	}
+}
+
+func TestSetConstant(t *testing.T) {
+	vm := NewVM()
+	if err := vm.Eval("const var PI = 3.14;"); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Set("PI", 3); err == nil {
+		t.Error("expected setting a constant to fail")
+	}
+	if pi, _ := vm.Get("PI"); pi != 3.14 {
+		t.Errorf("expected the constant to keep its value, got %v", pi)
+	}
+}
+
+func TestConvertSlicesAndMaps(t *testing.T) {
+	vm := NewVM()
+	if err := vm.Set("numbers", []int{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Set("ages", map[string]uint8{"ada": 36}); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Eval(`numbers[0] = "one"; ages["alan"] = [41, {"nested": true}];`); err != nil {
+		t.Fatal(err)
+	}
+
+	numbers, err := vm.Get("numbers")
+	if expected := []interface{}{"one", int64(2), int64(3)}; err != nil || !reflect.DeepEqual(numbers, expected) {
+		t.Errorf("expected %v, got %v, %v", expected, numbers, err)
+	}
+	ages, err := vm.Get("ages")
+	expected := map[interface{}]interface{}{
+		"ada":  int64(36),
+		"alan": []interface{}{int64(41), map[interface{}]interface{}{"nested": true}},
+	}
+	if err != nil || !reflect.DeepEqual(ages, expected) {
+		t.Errorf("expected %v, got %v, %v", expected, ages, err)
+	}
+	if inspected, _ := vm.Inspect("ages"); inspected != `{"ada": 36, "alan": [41, {"nested": true}]}` {
+		t.Errorf("unexpected dictionary %s", inspected)
+	}
+}
+
+func TestRegisterFunction(t *testing.T) {
+	vm := NewVM()
+	vm.RegisterFunction("add", 2, func(arguments []interface{}) (interface{}, error) {
+		return arguments[0].(int64) + arguments[1].(int64), nil
+	})
+	vm.RegisterFunction("count", VARIADIC_ARITY, func(arguments []interface{}) (interface{}, error) {
+		return len(arguments), nil
+	})
+	vm.RegisterFunction("fail", 1, func(arguments []interface{}) (interface{}, error) {
+		return nil, errors.New(arguments[0].(string))
+	})
+
+	if err := vm.Eval("var sum = add(1, 2); var none = count(); var three = count(1, nil, \"x\");"); err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]interface{}{"sum": int64(3), "none": int64(0), "three": int64(3)} {
+		if value, _ := vm.Get(name); value != expected {
+			t.Errorf("%s: expected %v, got %v", name, expected, value)
+		}
+	}
+
+	err := vm.Eval("add(1);")
+	if runtimeErr, isRuntimeErr := err.(*RuntimeError); !isRuntimeErr || runtimeErr.Message != "Expected 2 arguments but got 1" {
+		t.Errorf("expected an arity error, got %v", err)
+	}
+
+	err = vm.Eval("\nfail(\"host failure\");")
+	if runtimeErr, isRuntimeErr := err.(*RuntimeError); !isRuntimeErr || runtimeErr.Message != "host failure" || runtimeErr.Line != 2 {
+		t.Errorf("expected the host error as a runtime error on line 2, got %v", err)
+	}
+	if err := vm.Eval(`var caught; try { fail("again"); } catch (e) { caught = e.message; }`); err != nil {
+		t.Fatal(err)
+	}
+	if caught, _ := vm.Get("caught"); caught != "again" {
+		t.Errorf("expected the host error to be caught, got %v", caught)
+	}
+}
+
+func TestRuntimeErrorJSONAlwaysHasCallStack(t *testing.T) {
+	programs := map[string]string{
+		"print nil + 1;": `"callStack":[]`,
+		"fun f() { print nil + 1; }\nf();": `"callStack":[{"function":"f","file":"\u003ceval\u003e","line":2,"definitionLine":1}]`,
+	}
+	for program, expected := range programs {
+		encoded, err := json.Marshal(NewVM().Eval(program))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(encoded), expected) {
+			t.Errorf("%s: expected %s in %s", program, expected, encoded)
+		}
+	}
+}